@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// execCredentialAPIVersion is the client.authentication.k8s.io version spoken by
+// 'auth exec-credential' and written into --exec-credential kubeconfigs
+const execCredentialAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+// execCredential is the ExecCredential object a kubectl exec plugin prints on stdout
+type execCredential struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Status     execCredentialStatus `json:"status"`
+}
+
+type execCredentialStatus struct {
+	Token               string `json:"token"`
+	ExpirationTimestamp string `json:"expirationTimestamp,omitempty"`
+}
+
+// authStatus is the structured output of auth status
+type authStatus struct {
+	Authenticated bool   `json:"authenticated" yaml:"authenticated"`
+	Org           string `json:"org,omitempty" yaml:"org,omitempty"`
+	Region        string `json:"region,omitempty" yaml:"region,omitempty"`
+	ExpiresAt     string `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	ExpiresIn     string `json:"expiresIn,omitempty" yaml:"expiresIn,omitempty"`
+	Error         string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// authenticate exchanges the stored refresh token for an access token
+func authenticate(ctx context.Context, cfg *config.SpotConfig) (string, error) {
+	client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+	if err != nil {
+		return "", err
+	}
+	return client.Authenticate(ctx)
+}
+
+// tokenExpiry returns when an access token expires and the time left, both empty when the
+// token carries no expiry
+func tokenExpiry(token string) (expiresAt, expiresIn string) {
+	exp, ok := internal.TokenExpiry(token)
+	if !ok {
+		return "", ""
+	}
+	return exp.UTC().Format(time.RFC3339), time.Until(exp).Round(time.Second).String()
+}
+
+// authCmd represents the auth command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Authentication helpers",
+	Long:  `Commands for working with Rackspace Spot credentials.`,
+}
+
+// authStatusCmd represents the auth status command
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check whether the stored credentials can authenticate",
+	Long: `Exchange the stored refresh token for an access token and report whether it succeeded and
+when the access token expires. With an explicit --output the status is printed as an object
+instead of a message. Exits with code 4 when authentication fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		status := authStatus{Org: cfg.Org, Region: cfg.Region}
+		token, authErr := authenticate(cmd.Context(), cfg)
+		if authErr != nil {
+			status.Error = authErr.Error()
+		} else {
+			status.Authenticated = true
+			status.ExpiresAt, status.ExpiresIn = tokenExpiry(token)
+		}
+
+		if structuredOutput(cmd) {
+			if err := internal.OutputData(status, outputFormat); err != nil {
+				return err
+			}
+		} else if status.Authenticated {
+			msg := "Authenticated"
+			if status.Org != "" {
+				msg += " for organization " + status.Org
+			}
+			if status.ExpiresIn != "" {
+				msg += " (token expires in " + status.ExpiresIn + ")"
+			}
+			fmt.Println(msg)
+		}
+		if authErr != nil {
+			return &exitError{code: exitAuthFailed, err: fmt.Errorf("authentication failed: %w", authErr)}
+		}
+		return nil
+	},
+}
+
+// authExecCredentialCmd is the kubectl exec credential plugin used by kubeconfigs
+// written with 'cloudspaces get-config --exec-credential'
+var authExecCredentialCmd = &cobra.Command{
+	Use:    "exec-credential",
+	Short:  "Print a fresh token as a Kubernetes ExecCredential",
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		token, err := client.Authenticate(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		cred := execCredential{
+			APIVersion: execCredentialAPIVersion,
+			Kind:       "ExecCredential",
+			Status:     execCredentialStatus{Token: token},
+		}
+		// Without an expiry kubectl would cache the token for the whole process lifetime
+		if exp, ok := internal.TokenExpiry(token); ok {
+			cred.Status.ExpirationTimestamp = exp.UTC().Format(time.RFC3339)
+		}
+		// kubectl only understands this exact JSON shape, so --output does not apply
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cred)
+	},
+}
+
+// execCredentialCommand returns the command and arguments a kubeconfig should run to call
+// 'spotctl auth exec-credential'. spotctl is referenced by name when it is on the PATH so
+// that the kubeconfig survives upgrades that move the binary.
+func execCredentialCommand() (string, []string) {
+	command := "spotctl"
+	if _, err := exec.LookPath(command); err != nil {
+		if exe, exeErr := os.Executable(); exeErr == nil {
+			command = exe
+		}
+	}
+	args := []string{"auth", "exec-credential"}
+	if configDir != "" {
+		args = append(args, "--config-dir", configDir)
+	}
+	// kubectl runs the plugin without this invocation's flags, so keep the endpoints in use
+	if baseURL != "" {
+		args = append(args, "--base-url", baseURL)
+	}
+	if authURL != "" {
+		args = append(args, "--auth-url", authURL)
+	}
+	return command, args
+}
+
+// withExecCredential rewrites every user of a kubeconfig to obtain its token from the
+// spotctl exec credential plugin instead of a static token
+func withExecCredential(kubeconfig []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(kubeconfig, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	users, _ := doc["users"].([]interface{})
+	if len(users) == 0 {
+		return nil, fmt.Errorf("kubeconfig has no users to rewrite")
+	}
+	command, args := execCredentialCommand()
+	for _, u := range users {
+		user, ok := u.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("kubeconfig has a malformed users entry")
+		}
+		user["user"] = map[string]interface{}{
+			"exec": map[string]interface{}{
+				"apiVersion":         execCredentialAPIVersion,
+				"command":            command,
+				"args":               args,
+				"interactiveMode":    "Never",
+				"provideClusterInfo": false,
+			},
+		}
+	}
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authExecCredentialCmd)
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/rackspace-spot/spotctl/internal"
+	"github.com/spf13/cobra"
+)
+
+// batchSummary is the outcome of a batched delete
+type batchSummary struct {
+	Succeeded []string
+	Failed    map[string]error
+	Skipped   []string
+}
+
+// deleteInBatches deletes every name with at most parallelism concurrent requests, printing a
+// progress line per finished delete to out. Individual failures do not stop the batch; once ctx
+// is cancelled (or the user interrupts) no new deletes are started and the rest are skipped.
+func deleteInBatches(ctx context.Context, out io.Writer, what string, names []string, parallelism int, del func(ctx context.Context, name string) error) batchSummary {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var mu sync.Mutex
+	summary := batchSummary{Failed: map[string]error{}}
+	done := 0
+	forEachLimit(len(names), parallelism, func(i int) {
+		name := names[i]
+		if ctx.Err() != nil {
+			mu.Lock()
+			summary.Skipped = append(summary.Skipped, name)
+			mu.Unlock()
+			return
+		}
+		err := del(ctx, name)
+
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if err != nil {
+			summary.Failed[name] = err
+			if !quiet {
+				fmt.Fprintf(out, "[%d/%d] %s '%s' %s: %v\n", done, len(names), what, name, color.RedString("failed"), err)
+			}
+			return
+		}
+		summary.Succeeded = append(summary.Succeeded, name)
+		if !quiet {
+			fmt.Fprintf(out, "[%d/%d] %s '%s' %s\n", done, len(names), what, name, color.GreenString("deleted"))
+		}
+	})
+	return summary
+}
+
+// err returns an error describing the failed and skipped deletes, or nil if all succeeded
+func (s batchSummary) err(what string) error {
+	if len(s.Failed) == 0 && len(s.Skipped) == 0 {
+		return nil
+	}
+	var names []string
+	for name := range s.Failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msg := fmt.Sprintf("%d %s(s) failed to delete", len(s.Failed), what)
+	if len(names) > 0 {
+		msg += ": " + strings.Join(names, ", ")
+	}
+	if len(s.Skipped) > 0 {
+		msg += fmt.Sprintf("; %d skipped after cancellation", len(s.Skipped))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// print writes the final succeeded/failed/skipped counts to out
+func (s batchSummary) print(out io.Writer, what string) {
+	fmt.Fprintf(out, "Deleted %d %s(s), %d failed", len(s.Succeeded), what, len(s.Failed))
+	if len(s.Skipped) > 0 {
+		fmt.Fprintf(out, ", %d skipped", len(s.Skipped))
+	}
+	fmt.Fprintln(out)
+}
+
+// deletedResource is the structured result of a delete command. DryRun is set when the
+// resource would have been deleted.
+type deletedResource struct {
+	Deleted string `json:"deleted" yaml:"deleted"`
+	Kind    string `json:"kind" yaml:"kind"`
+	DryRun  bool   `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+}
+
+// reportDeleted prints the result of deleting one resource: a deletedResource with -o,
+// otherwise message on stderr unless --quiet is set
+func reportDeleted(cmd *cobra.Command, kind, name, message string) error {
+	if structuredOutput(cmd) {
+		return internal.OutputData(deletedResource{Deleted: name, Kind: kind}, outputFormat)
+	}
+	if !quiet {
+		fmt.Fprintln(os.Stderr, message)
+	}
+	return nil
+}
+
+// reportDryRunDeleted prints the result of a dry-run delete of one resource: a deletedResource
+// with -o, otherwise message on stderr
+func reportDryRunDeleted(cmd *cobra.Command, kind, name, message string) error {
+	if structuredOutput(cmd) {
+		return internal.OutputData(deletedResource{Deleted: name, Kind: kind, DryRun: true}, outputFormat)
+	}
+	fmt.Fprintln(os.Stderr, message)
+	return nil
+}
+
+// reportBatchDryRunDeleted prints the resources a dry-run batched delete would remove: a list of
+// deletedResource with -o, otherwise one message per resource on stderr
+func reportBatchDryRunDeleted(cmd *cobra.Command, kind string, names []string, message func(name string) string) error {
+	if structuredOutput(cmd) {
+		deleted := make([]deletedResource, 0, len(names))
+		for _, name := range names {
+			deleted = append(deleted, deletedResource{Deleted: name, Kind: kind, DryRun: true})
+		}
+		return internal.OutputData(deleted, outputFormat)
+	}
+	for _, name := range names {
+		fmt.Fprintln(os.Stderr, message(name))
+	}
+	return nil
+}
+
+// reportBatchDeleted prints the summary of a batched delete to stderr unless --quiet is set,
+// and the deleted resources with -o
+func reportBatchDeleted(cmd *cobra.Command, kind, what string, s batchSummary) error {
+	deleted := make([]deletedResource, 0, len(s.Succeeded))
+	for _, name := range s.Succeeded {
+		deleted = append(deleted, deletedResource{Deleted: name, Kind: kind})
+	}
+	return reportBatchResult(cmd, what, s, deleted)
+}
+
+// reportBatchResult is reportBatchDeleted for commands that describe the deleted resources
+// with their own result type
+func reportBatchResult(cmd *cobra.Command, what string, s batchSummary, result interface{}) error {
+	if !quiet {
+		s.print(os.Stderr, what)
+	}
+	if structuredOutput(cmd) {
+		if err := internal.OutputData(result, outputFormat); err != nil {
+			return err
+		}
+	}
+	return s.err(what)
+}
+
+// confirmYesNo asks a y/N question on stderr and reports whether the user answered yes,
+// printing "Aborted." otherwise
+func confirmYesNo(format string, args ...interface{}) bool {
+	color.New(color.FgYellow).Fprintf(os.Stderr, format, args...)
+
+	var response string
+	_, err := fmt.Scanln(&response)
+	if err != nil || (response != "y" && response != "Y") {
+		fmt.Fprintln(os.Stderr, "Aborted.")
+		return false
+	}
+	return true
+}
+
+// confirmBatchDelete asks before deleting names unless --yes is set
+func confirmBatchDelete(cmd *cobra.Command, what string, names []string) bool {
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return true
+	}
+	if !confirmYesNo("About to delete %d %s(s):\n  %s\nAre you sure? (y/N): ", len(names), what, strings.Join(names, "\n  ")) {
+		return false
+	}
+	return true
+}
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fatih/color"
@@ -23,10 +27,11 @@ import (
 	"github.com/spf13/pflag"
 
 	"gopkg.in/yaml.v3"
-	"k8s.io/klog/v2"
+	"k8s.io/klog"
 )
 
 type interactiveModel struct {
+	ctx         context.Context
 	client      *internal.Client
 	cfg         *config.SpotConfig
 	params      createCloudspaceParams
@@ -34,6 +39,8 @@ type interactiveModel struct {
 	steps       []func() error
 	err         error
 	cancelled   bool
+	// maxBidMultiple caps wizard bids at this multiple of the on-demand price; 0 disables it
+	maxBidMultiple float64
 }
 
 // createCloudspaceParams holds all parameters needed for cloudspace creation
@@ -47,6 +54,171 @@ type createCloudspaceParams struct {
 	ConfigPath           string                     `json:"-" yaml:"-"`
 	SpotNodePools        []rxtspot.SpotNodePool     `json:"spotNodePools,omitempty" yaml:"spotNodePools,omitempty"`
 	OnDemandNodePools    []rxtspot.OnDemandNodePool `json:"onDemandNodePools,omitempty" yaml:"onDemandNodePools,omitempty"`
+	// SpotPriorities and OnDemandPriorities hold the creation priority of the pool at the same index
+	SpotPriorities     []int `json:"-" yaml:"-"`
+	OnDemandPriorities []int `json:"-" yaml:"-"`
+}
+
+// poolPriorities captures the optional per-pool priority of a manifest, which the SDK
+// node pool types don't model
+type poolPriorities struct {
+	SpotNodePools []struct {
+		Priority int `json:"priority" yaml:"priority"`
+	} `json:"spotnodepools" yaml:"spotnodepools"`
+	OnDemandNodePools []struct {
+		Priority int `json:"priority" yaml:"priority"`
+	} `json:"ondemandnodepools" yaml:"ondemandnodepools"`
+}
+
+// poolCreationStep identifies one node pool of createCloudspaceParams to create
+type poolCreationStep struct {
+	spot     bool
+	index    int
+	priority int
+}
+
+// poolCreationOrder returns the node pools of params sorted by priority, lower first.
+// Priority only affects the order in which pools are submitted, not when they become ready.
+func poolCreationOrder(params *createCloudspaceParams) []poolCreationStep {
+	var steps []poolCreationStep
+	for i := range params.SpotNodePools {
+		step := poolCreationStep{spot: true, index: i}
+		if i < len(params.SpotPriorities) {
+			step.priority = params.SpotPriorities[i]
+		}
+		steps = append(steps, step)
+	}
+	for i := range params.OnDemandNodePools {
+		step := poolCreationStep{index: i}
+		if i < len(params.OnDemandPriorities) {
+			step.priority = params.OnDemandPriorities[i]
+		}
+		steps = append(steps, step)
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].priority < steps[j].priority
+	})
+	return steps
+}
+
+// cloudspaceManifest is the on-disk representation of a cloudspace and its node pools,
+// as accepted by --config and produced by 'cloudspaces edit'
+type cloudspaceManifest struct {
+	CloudSpace        rxtspot.CloudSpace         `json:"cloudspace" yaml:"cloudspace"`
+	SpotNodePools     []rxtspot.SpotNodePool     `json:"spotnodepools" yaml:"spotnodepools"`
+	OnDemandNodePools []rxtspot.OnDemandNodePool `json:"ondemandnodepools" yaml:"ondemandnodepools"`
+}
+
+// createCloudspaceResult is the structured result of a cloudspace create, including
+// every node pool that was created alongside it
+type createCloudspaceResult struct {
+	Cloudspace        *rxtspot.CloudSpace         `json:"cloudspace" yaml:"cloudspace"`
+	SpotNodePools     []*rxtspot.SpotNodePool     `json:"spotNodePools" yaml:"spotNodePools"`
+	OnDemandNodePools []*rxtspot.OnDemandNodePool `json:"onDemandNodePools" yaml:"onDemandNodePools"`
+	KubeconfigPath    string                      `json:"kubeconfigPath,omitempty" yaml:"kubeconfigPath,omitempty"`
+	FailedNodePools   []failedNodePool            `json:"failedNodePools,omitempty" yaml:"failedNodePools,omitempty"`
+}
+
+// failedNodePool records a node pool that could not be created with --no-rollback
+type failedNodePool struct {
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type" yaml:"type"`
+	ServerClass string `json:"serverClass" yaml:"serverClass"`
+	Desired     int    `json:"desired" yaml:"desired"`
+	Error       string `json:"error" yaml:"error"`
+}
+
+// nodePoolRef identifies a node pool submitted by cloudspaces create
+type nodePoolRef struct {
+	Name        string
+	Type        string
+	ServerClass string
+	Desired     int
+}
+
+// failed records the pool as failed with err
+func (r nodePoolRef) failed(err error) failedNodePool {
+	return failedNodePool{Name: r.Name, Type: r.Type, ServerClass: r.ServerClass, Desired: r.Desired, Error: err.Error()}
+}
+
+// verifiedNodePools is the outcome of reading back the node pools created by cloudspaces create
+type verifiedNodePools struct {
+	spot     []*rxtspot.SpotNodePool
+	onDemand []*rxtspot.OnDemandNodePool
+	failed   []unverifiedNodePool
+}
+
+type unverifiedNodePool struct {
+	pool nodePoolRef
+	err  error
+}
+
+// verifyNodePools reads back every created pool with at most parallelism concurrent requests.
+// Verified pools are returned in the order they were created.
+func verifyNodePools(ctx context.Context, client *internal.Client, org string, created []nodePoolRef, parallelism int) *verifiedNodePools {
+	spot := make([]*rxtspot.SpotNodePool, len(created))
+	onDemand := make([]*rxtspot.OnDemandNodePool, len(created))
+	errs := make([]error, len(created))
+	forEachLimit(len(created), parallelism, func(i int) {
+		pool := created[i]
+		if pool.Type == "spot" {
+			p, err := client.GetAPI().GetSpotNodePool(ctx, org, pool.Name)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to verify creation of spot node pool %s: %w", pool.Name, err)
+			}
+			spot[i] = p
+			return
+		}
+		p, err := client.GetAPI().GetOnDemandNodePool(ctx, org, pool.Name)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to verify creation of on-demand node pool %s: %w", pool.Name, err)
+		}
+		onDemand[i] = p
+	})
+
+	v := &verifiedNodePools{}
+	for i, pool := range created {
+		switch {
+		case errs[i] != nil:
+			v.failed = append(v.failed, unverifiedNodePool{pool: pool, err: errs[i]})
+		case pool.Type == "spot":
+			v.spot = append(v.spot, spot[i])
+		default:
+			v.onDemand = append(v.onDemand, onDemand[i])
+		}
+	}
+	return v
+}
+
+// nodePoolsInCloudspace checks that every created pool is listed by the cloudspace, without
+// reading the pools individually
+func nodePoolsInCloudspace(cs *rxtspot.CloudSpace, created []nodePoolRef) *verifiedNodePools {
+	spot := map[string]*rxtspot.SpotNodePool{}
+	for _, p := range cs.SpotNodepools {
+		if p != nil {
+			spot[p.Name] = p
+		}
+	}
+	onDemand := map[string]*rxtspot.OnDemandNodePool{}
+	for _, p := range cs.OnDemandNodePools {
+		if p != nil {
+			onDemand[p.Name] = p
+		}
+	}
+
+	v := &verifiedNodePools{}
+	for _, pool := range created {
+		if p, ok := spot[pool.Name]; ok && pool.Type == "spot" {
+			v.spot = append(v.spot, p)
+			continue
+		}
+		if p, ok := onDemand[pool.Name]; ok && pool.Type == "ondemand" {
+			v.onDemand = append(v.onDemand, p)
+			continue
+		}
+		v.failed = append(v.failed, unverifiedNodePool{pool: pool, err: fmt.Errorf("%s node pool %s is missing from cloudspace %s after creation", pool.Type, pool.Name, cs.Name)})
+	}
+	return v
 }
 
 const (
@@ -75,10 +247,11 @@ func init() {
 	cloudspacesCmd.AddCommand(cloudspacesGetCmd)
 	cloudspacesCmd.AddCommand(cloudspacesDeleteCmd)
 	cloudspacesCmd.AddCommand(cloudspacesGetConfigCmd)
+	cloudspacesCmd.AddCommand(cloudspacesEditCmd)
 
 	// Add flags for cloudspaces list
 	cloudspacesListCmd.Flags().String("org", "", "Organization ID")
-	cloudspacesListCmd.Flags().StringP("output", "o", "json", "Output format (json, table, yaml)")
+	cloudspacesListCmd.Flags().Bool("compact-pools", false, "Replace node pool details with a count summary")
 
 	// Add flags for cloudspaces create
 	cloudspacesCreateCmd.Flags().String("name", "", "Cloudspace name")
@@ -87,27 +260,55 @@ func init() {
 	cloudspacesCreateCmd.Flags().StringP("kubernetes-version", "", "1.31.1", "Kubernetes version (default: 1.31.1)")
 	cloudspacesCreateCmd.Flags().String("preemption-webhook-url", "", "Preemption webhook URL")
 
-	cloudspacesCreateCmd.Flags().StringArray("spot-nodepool", []string{}, "Spot nodepool details in key=value format (e.g., desired=1,serverclass=gp.vs1.medium-ord,bidprice=0.08)")
-	cloudspacesCreateCmd.Flags().StringArray("ondemand-nodepool", []string{}, "Ondemand nodepool details in key=value format (e.g., desired=1,serverclass=gp.vs1.medium-ord)")
+	cloudspacesCreateCmd.Flags().StringArray("spot-nodepool", []string{}, "Spot nodepool details in key=value format (e.g., desired=1,serverclass=gp.vs1.medium-ord,bidprice=0.08,priority=1)")
+	cloudspacesCreateCmd.Flags().StringArray("ondemand-nodepool", []string{}, "Ondemand nodepool details in key=value format (e.g., desired=1,serverclass=gp.vs1.medium-ord,priority=0)")
 	cloudspacesCreateCmd.Flags().String("config", "", "Path to config file (YAML or JSON)")
+	cloudspacesCreateCmd.Flags().Bool("expand-env", false, "Replace ${VAR} and ${VAR:-default} in the --config file with environment variables before parsing")
 	cloudspacesCreateCmd.Flags().StringP("cni", "", "calico", "CNI (default: calico)")
+	cloudspacesCreateCmd.Flags().Float64("max-bid-multiple", 2, "In interactive mode, reject spot bids above this multiple of the on-demand price (0 disables the check)")
+	cloudspacesCreateCmd.Flags().Float64("min-bid-buffer", 0, "Automatically raise spot bids below the server class minimum to the minimum plus this amount")
+	cloudspacesCreateCmd.Flags().String("generate-name", "", "Create the cloudspace under this prefix followed by a random suffix (e.g. ci- gives ci-3f9a2)")
+	addRetryOnConflictFlag(cloudspacesCreateCmd, "with a newly generated name (requires --generate-name)")
+	cloudspacesCreateCmd.Flags().Bool("if-not-exists", false, "Succeed without changes, printing the existing cloudspace and saving its kubeconfig when requested, when a cloudspace with the name already exists")
+	cloudspacesCreateCmd.Flags().BoolP("interactive", "i", false, "Run the interactive wizard even when other flags are set; their values become the wizard defaults")
+	cloudspacesCreateCmd.Flags().Bool("trace", false, "Print the elapsed time of each creation phase to stderr")
+	cloudspacesCreateCmd.Flags().Duration("api-timeout", 30*time.Second, "Maximum time the interactive wizard waits for the API to list regions or server classes before falling back to manual entry")
+	cloudspacesCreateCmd.Flags().Bool("no-rollback", false, "Keep the cloudspace and the other pools when a node pool fails; failed pools are listed in the output and the command exits with code 3")
+	cloudspacesCreateCmd.Flags().Bool("skip-verify", false, "Do not read each node pool back after creating it; the pools are only checked against the final cloudspace")
+	cloudspacesCreateCmd.Flags().Int("parallelism", defaultParallelism, "Maximum number of concurrent requests when verifying the created node pools")
+	cloudspacesCreateCmd.Flags().Bool("wait", false, "Wait until the cloudspace is ready")
+	cloudspacesCreateCmd.Flags().Duration("timeout", 30*time.Minute, "Maximum time to wait with --wait or --with-kubeconfig")
+	cloudspacesCreateCmd.Flags().Bool("with-kubeconfig", false, "Wait until the cloudspace is ready, then save its kubeconfig and include the path in the output (implies --wait)")
+	cloudspacesCreateCmd.Flags().Bool("print-kubeconfig-path", false, "Print only the absolute path of the saved kubeconfig on stdout, ignoring --output (implies --with-kubeconfig)")
+	cloudspacesCreateCmd.Flags().String("kubeconfig-dir", "", "Directory for the kubeconfig saved by --with-kubeconfig (default: ~/.kube)")
+	cloudspacesCreateCmd.Flags().Bool("overwrite-kubeconfig", false, "Replace an existing kubeconfig file with --with-kubeconfig")
 
 	// Add flags for cloudspaces get
 	cloudspacesGetCmd.Flags().String("name", "", "Cloudspace name (required)")
 	cloudspacesGetCmd.Flags().String("org", "", "Organization ID")
 	cloudspacesGetCmd.MarkFlagRequired("name")
+	cloudspacesGetCmd.Flags().BoolP("watch", "w", false, "Keep polling and print the cloudspace whenever it changes (newline-delimited JSON when piped with -o json)")
+	cloudspacesGetCmd.Flags().Duration("watch-interval", 5*time.Second, "Polling interval for --watch")
 
 	// Add flags for cloudspaces get-config
 	cloudspacesGetConfigCmd.Flags().String("name", "", "Cloudspace name (required)")
 	cloudspacesGetConfigCmd.Flags().String("org", "", "Organization ID")
 	cloudspacesGetConfigCmd.Flags().String("file", "", "Output file name (default: <cloudspace_name>.yaml)")
+	cloudspacesGetConfigCmd.Flags().Bool("overwrite", false, "Overwrite the output file if it already exists")
+	cloudspacesGetConfigCmd.Flags().Bool("merge", false, "Merge the clusters, contexts and users into the default kubeconfig ($KUBECONFIG or ~/.kube/config) instead of writing a separate file")
+	cloudspacesGetConfigCmd.Flags().Bool("use", false, "With --merge, also make the cloudspace's context the current-context")
+	cloudspacesGetConfigCmd.Flags().Bool("exec-credential", false, "Have kubectl fetch a fresh token from 'spotctl auth exec-credential' instead of embedding a static one that goes stale")
 	cloudspacesGetConfigCmd.MarkFlagRequired("name")
 
 	// Add flags for cloudspaces delete
-	cloudspacesDeleteCmd.Flags().String("name", "", "Cloudspace name (required)")
+	cloudspacesDeleteCmd.Flags().String("name", "", "Cloudspace name (required unless --all)")
 	cloudspacesDeleteCmd.Flags().String("org", "", "Organization ID")
-	cloudspacesDeleteCmd.MarkFlagRequired("name")
+	cloudspacesDeleteCmd.Flags().Bool("all", false, "Delete every cloudspace in the organization")
+	cloudspacesDeleteCmd.Flags().Int("parallelism", defaultParallelism, "Maximum number of concurrent deletes with --all")
 	cloudspacesDeleteCmd.Flags().BoolP("yes", "y", false, "Automatic yes to prompts; assume \"yes\" as answer")
+	cloudspacesDeleteCmd.Flags().Bool("wait-for-delete", false, "Wait until the deletion has completed")
+	cloudspacesDeleteCmd.Flags().Duration("timeout", 10*time.Minute, "Maximum time to wait with --wait-for-delete")
+	cloudspacesDeleteCmd.Flags().Bool("dry-run", false, "Verify the cloudspace exists and print what would be deleted without deleting it")
 }
 
 // cloudspacesListCmd represents the cloudspaces list command
@@ -117,14 +318,12 @@ var cloudspacesListCmd = &cobra.Command{
 	Long:  `List all cloudspaces in an organization.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.GetCLIEssentials(cmd)
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
+		if err != nil {
+			return err
 		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
@@ -132,12 +331,35 @@ var cloudspacesListCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		cloudspaces, err := client.GetAPI().ListCloudspaces(context.Background(), org)
+		list, err := client.GetAPI().ListCloudspaces(context.Background(), org)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
 
-		return internal.OutputData(cloudspaces, outputFormat)
+		if compact, _ := cmd.Flags().GetBool("compact-pools"); compact {
+			items, err := toGenericMaps(list.Items)
+			if err != nil {
+				return err
+			}
+			compactNodePools(items)
+			return internal.OutputData(items, outputFormat)
+		}
+
+		// In table mode show derived pool and node counts instead of the raw node pool fields
+		if strings.EqualFold(outputFormat, "table") {
+			items, err := toGenericMaps(list.Items)
+			if err != nil {
+				return err
+			}
+			for _, cs := range items {
+				summary := summarizeNodePools(cs, true)
+				cs["pools"] = summary.Spot + summary.OnDemand
+				cs["nodes"] = summary.TotalDesired
+			}
+			return internal.OutputData(items, outputFormat)
+		}
+
+		return internal.OutputData(list, outputFormat)
 	},
 }
 
@@ -148,32 +370,42 @@ var cloudspacesDeleteCmd = &cobra.Command{
 	Long:  `Delete a cloudspace and all its resources.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name, _ := cmd.Flags().GetString("name")
-		if name == "" {
+		all, _ := cmd.Flags().GetBool("all")
+		if all && name != "" {
+			return fmt.Errorf("--all cannot be combined with --name")
+		}
+		if name == "" && !all {
 			return fmt.Errorf("name is required")
 		}
 		cfg, err := config.GetCLIEssentials(cmd)
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		if all {
+			return deleteAllCloudspaces(cmd, cfg, org)
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+			if _, err := client.GetCloudspace(context.Background(), org, name); err != nil {
+				if rxtspot.IsNotFound(err) {
+					return fmt.Errorf("cloudspace '%s' not found", name)
+				}
+				return fmt.Errorf("%w", err)
+			}
+			return reportDryRunDeleted(cmd, "cloudspace", name, fmt.Sprintf("cloudspace - %s would be deleted (dry run)", name))
 		}
 		yes, _ := cmd.Flags().GetBool("yes")
 		if !yes {
 			// Interactive prompt
-			prompt := color.New(color.FgYellow).PrintfFunc()
-			prompt("Are you sure you want to delete cloudspace '%s'? (y/N): ", name)
-
-			var response string
-			_, err := fmt.Scanln(&response)
-			if err != nil || (response != "y" && response != "Y") {
-				fmt.Println("Aborted.")
+			if !confirmYesNo("Are you sure you want to delete cloudspace '%s'? (y/N): ", name) {
 				return nil
 			}
 		}
@@ -183,6 +415,7 @@ var cloudspacesDeleteCmd = &cobra.Command{
 		}
 
 		err = client.GetAPI().DeleteCloudspace(context.Background(), org, name)
+		client.InvalidateCloudspace(org, name)
 		if err != nil {
 			if rxtspot.IsNotFound(err) {
 				return fmt.Errorf("cloudspace '%s' not found", name)
@@ -196,11 +429,58 @@ var cloudspacesDeleteCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		fmt.Printf("Cloudspace '%s' deleted successfully\n", name)
-		return nil
+		if wait, _ := cmd.Flags().GetBool("wait-for-delete"); wait {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			err := waitForDeletion(cmd.Context(), fmt.Sprintf("cloudspace '%s'", name), timeout, func(ctx context.Context) error {
+				return client.CheckCloudspace(ctx, org, name)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return reportDeleted(cmd, "cloudspace", name, fmt.Sprintf("Cloudspace '%s' deleted successfully", name))
 	},
 }
 
+// deleteAllCloudspaces deletes every cloudspace in org in concurrent batches
+func deleteAllCloudspaces(cmd *cobra.Command, cfg *config.SpotConfig, org string) error {
+	client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx := cmd.Context()
+	cloudspaces, err := client.GetAPI().ListCloudspaces(ctx, org)
+	if err != nil {
+		return fmt.Errorf("failed to list cloudspaces: %w", err)
+	}
+	var names []string
+	for _, cs := range cloudspaces.Items {
+		names = append(names, cs.Name)
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(os.Stderr, "No cloudspaces found in organization %s\n", org)
+		return nil
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return reportBatchDryRunDeleted(cmd, "cloudspace", names, func(name string) string {
+			return fmt.Sprintf("cloudspace - %s would be deleted (dry run)", name)
+		})
+	}
+	if !confirmBatchDelete(cmd, "cloudspace", names) {
+		return nil
+	}
+
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	summary := deleteInBatches(ctx, os.Stderr, "cloudspace", names, parallelism, func(ctx context.Context, name string) error {
+		err := client.GetAPI().DeleteCloudspace(ctx, org, name)
+		client.InvalidateCloudspace(org, name)
+		return err
+	})
+	return reportBatchDeleted(cmd, "cloudspace", "cloudspace", summary)
+}
+
 // cloudspacesCreateCmd represents the cloudspaces create command
 var cloudspacesCreateCmd = &cobra.Command{
 	Use:   "create",
@@ -216,9 +496,15 @@ var cloudspacesCreateCmd = &cobra.Command{
 		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 		go func() {
 			<-sigCh
-			fmt.Println("\n\nOperation cancelled by user")
+			fmt.Fprintln(os.Stderr, "\n\nOperation cancelled by user")
 			cancel()
 		}()
+
+		// Record phase timings when --trace is set; the summary is printed on every exit path
+		traceEnabled, _ := cmd.Flags().GetBool("trace")
+		trace := newPhaseTrace(traceEnabled)
+		defer trace.print(os.Stderr)
+
 		// Get CLI configuration
 		cfg, err := config.GetCLIEssentials(cmd)
 		if err != nil {
@@ -226,19 +512,34 @@ var cloudspacesCreateCmd = &cobra.Command{
 		}
 
 		// Initialize client
+		phaseStart := time.Now()
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
 		if err != nil {
 			return fmt.Errorf("failed to initialize client: %w", err)
 		}
+		trace.track("auth", phaseStart)
 
 		// Check if we're in interactive mode
-		interactive := isInteractiveMode(cmd)
+		interactive, err := isInteractiveMode(cmd)
+		if err != nil {
+			return err
+		}
+		printKubeconfigPath, _ := cmd.Flags().GetBool("print-kubeconfig-path")
+		if printKubeconfigPath && interactive {
+			return fmt.Errorf("--print-kubeconfig-path cannot be combined with --interactive")
+		}
 
 		// Load parameters based on mode
 		var params *createCloudspaceParams
 		if interactive {
-			// Interactive mode - collect input from user
-			params, err = collectInteractiveInput(client, cfg)
+			apiTimeout, _ := cmd.Flags().GetDuration("api-timeout")
+			internal.SetPromptFetchTimeout(apiTimeout)
+			// Interactive mode - collect input from user, starting from any values set by flags
+			maxBidMultiple, _ := cmd.Flags().GetFloat64("max-bid-multiple")
+			if maxBidMultiple < 0 {
+				return fmt.Errorf("--max-bid-multiple must not be negative")
+			}
+			params, err = collectInteractiveInput(ctx, client, cfg, wizardDefaultsFromFlags(cmd, cfg), maxBidMultiple)
 			if err != nil {
 				return fmt.Errorf("failed to collect interactive input: %w", err)
 			}
@@ -251,17 +552,122 @@ var cloudspacesCreateCmd = &cobra.Command{
 		}
 
 		// Set default values
-		if params.Org == "" && cfg.Org != "" {
-			params.Org = cfg.Org
+		if params.Org == "" {
+			params.Org = configDefault("org", cfg.Org)
+		}
+		if params.Region == "" {
+			params.Region = configDefault("region", cfg.Region)
 		}
-		if params.Region == "" && cfg.Region != "" {
-			params.Region = cfg.Region
+		if prefix, _ := cmd.Flags().GetString("generate-name"); prefix != "" {
+			if params.Name != "" {
+				return fmt.Errorf("--generate-name cannot be combined with --name")
+			}
+			params.Name, err = generateCloudspaceName(ctx, client, params.Org, prefix)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Using generated name %s\n", params.Name)
+		} else if cmd.Flags().Changed("retry-on-conflict") {
+			return fmt.Errorf("--retry-on-conflict requires --generate-name")
+		}
+		// Offer a region picker instead of failing on a missing or mistyped region
+		if !interactive && !isValidRegion(params.Region) && canPrompt() {
+			if params.Region == "" {
+				fmt.Fprintln(os.Stderr, "No region specified.")
+			} else {
+				if suggestion, ok := suggestRegion(params.Region); ok {
+					fmt.Fprintf(os.Stderr, "Region '%s' is not valid, did you mean '%s'?\n", params.Region, suggestion)
+				} else {
+					fmt.Fprintf(os.Stderr, "Region '%s' is not valid.\n", params.Region)
+				}
+			}
+			region, err := promptForValidRegion(ctx)
+			if err != nil {
+				return fmt.Errorf("region selection failed: %w", err)
+			}
+			params.Region = region
 		}
 		// Validate parameters
 		if err := validateCreateParams(params, interactive); err != nil {
 			return fmt.Errorf("validation failed: %w", err)
 		}
 
+		// Resolve the kubeconfig path up front so an existing file is reported before anything is created
+		withKubeconfig, _ := cmd.Flags().GetBool("with-kubeconfig")
+		wait, _ := cmd.Flags().GetBool("wait")
+		skipVerify, _ := cmd.Flags().GetBool("skip-verify")
+		overwriteKubeconfig, _ := cmd.Flags().GetBool("overwrite-kubeconfig")
+		withKubeconfig = withKubeconfig || printKubeconfigPath
+		wait = wait || withKubeconfig
+		var kubeconfigFile string
+		if withKubeconfig {
+			dir, _ := cmd.Flags().GetString("kubeconfig-dir")
+			kubeconfigFile, err = absKubeconfigPath(dir, params.Name)
+			if err != nil {
+				return err
+			}
+		}
+
+		if ifNotExists, _ := cmd.Flags().GetBool("if-not-exists"); ifNotExists {
+			err := client.CheckCloudspace(ctx, params.Org, params.Name)
+			if err == nil {
+				existing, err := client.GetCloudspace(ctx, params.Org, params.Name)
+				if err != nil {
+					return fmt.Errorf("failed to get existing cloudspace %s: %w", params.Name, err)
+				}
+				fmt.Fprintf(os.Stderr, "Cloudspace '%s' already exists, skipping creation\n", params.Name)
+				if !withKubeconfig {
+					return internal.OutputData(existing, outputFormat)
+				}
+				// Rerunning the same command must still leave a usable kubeconfig behind
+				if _, statErr := os.Stat(kubeconfigFile); statErr == nil && !overwriteKubeconfig {
+					fmt.Fprintf(os.Stderr, "Keeping existing kubeconfig %s\n", kubeconfigFile)
+				} else {
+					timeout, _ := cmd.Flags().GetDuration("timeout")
+					existing, err = waitForCloudspaceReady(ctx, client, params.Org, params.Name, timeout, ui.NewStepTracker(os.Stderr, 0, quiet))
+					if err != nil {
+						return err
+					}
+					if err := writeKubeconfig(ctx, client, params.Org, params.Name, kubeconfigFile, false); err != nil {
+						return err
+					}
+				}
+				return outputCreateResult(createCloudspaceResult{Cloudspace: existing, KubeconfigPath: kubeconfigFile}, printKubeconfigPath)
+			}
+			if !rxtspot.IsNotFound(err) {
+				return fmt.Errorf("failed to check whether cloudspace %s exists: %w", params.Name, err)
+			}
+		}
+
+		// Raise bids that are below the server class minimum when --min-bid-buffer is set
+		if cmd.Flags().Changed("min-bid-buffer") && len(params.SpotNodePools) > 0 {
+			buffer, _ := cmd.Flags().GetFloat64("min-bid-buffer")
+			minBids, err := getMinBidPrices(ctx, client, params.Region)
+			if err != nil {
+				return err
+			}
+			for i, pool := range params.SpotNodePools {
+				minBid, ok := minBids[pool.ServerClass]
+				if !ok {
+					continue
+				}
+				adjusted, raised, err := raiseBidToMinimum(pool.BidPrice, minBid, buffer)
+				if err != nil {
+					return fmt.Errorf("invalid bid price for pool %s: %w", pool.Name, err)
+				}
+				if raised {
+					fmt.Fprintf(os.Stderr, "Raised bid for spot node pool %s from $%s to $%s (minimum: $%s)\n", pool.Name, pool.BidPrice, adjusted, minBid)
+					params.SpotNodePools[i].BidPrice = adjusted
+				}
+			}
+		}
+
+		if withKubeconfig && !overwriteKubeconfig {
+			if _, statErr := os.Stat(kubeconfigFile); statErr == nil {
+				return fmt.Errorf("file %s already exists (use --overwrite-kubeconfig to replace it)", kubeconfigFile)
+			}
+		}
+
 		// Check if context was cancelled before starting creation
 		select {
 		case <-ctx.Done():
@@ -280,70 +686,129 @@ var cloudspacesCreateCmd = &cobra.Command{
 			PreemptionWebhookURL: params.PreemptionWebhookURL,
 		}
 
-		// Temporary debug to verify values before API call
-		fmt.Printf("Creating cloudspace: Name=%q Org=%q Region=%q K8s=%q CNI=%q\n",
+		klog.V(1).Infof("Creating cloudspace: Name=%q Org=%q Region=%q K8s=%q CNI=%q",
 			cloudspace.Name, cloudspace.Org, cloudspace.Region, cloudspace.KubernetesVersion, cloudspace.CNI)
 
-		if err := client.GetAPI().CreateCloudspace(ctx, cloudspace); err != nil {
-			return fmt.Errorf("failed to create cloudspace: %w", err)
+		// One step for the cloudspace, one per pool and one for the final fetch, plus waiting
+		// and the kubeconfig download when requested
+		totalSteps := 2 + len(params.SpotNodePools) + len(params.OnDemandNodePools)
+		if !skipVerify && totalSteps > 2 {
+			totalSteps++
+		}
+		if wait {
+			totalSteps++
+		}
+		if withKubeconfig {
+			totalSteps++
+		}
+		steps := ui.NewStepTracker(os.Stderr, totalSteps, quiet)
+		steps.Start("Creating cloudspace %s", cloudspace.Name)
+		phaseStart = time.Now()
+		// A conflict on a generated name means another client took it after it was checked;
+		// any other conflict is an existing cloudspace that retrying would not get past
+		prefix, _ := cmd.Flags().GetString("generate-name")
+		retries := 0
+		if prefix != "" {
+			if retries, err = conflictRetries(cmd); err != nil {
+				return steps.Fail(err)
+			}
 		}
-		// Create spot node pools if any
-		for _, pool := range params.SpotNodePools {
-			// Check if context was cancelled before each pool creation
-			select {
-			case <-ctx.Done():
-				// Clean up the cloudspace if we're cancelled mid-creation
-				if err := client.GetAPI().DeleteCloudspace(ctx, params.Org, params.Name); err != nil {
-					klog.Warningf("Failed to clean up cloudspace after cancellation: %v", err)
+		err = retryOnConflict(ctx, "cloudspace "+cloudspace.Name, retries, func(attempt int) error {
+			if attempt > 0 {
+				name, err := generateCloudspaceName(ctx, client, params.Org, prefix)
+				if err != nil {
+					return err
+				}
+				params.Name, cloudspace.Name = name, name
+				fmt.Fprintf(os.Stderr, "Using generated name %s\n", params.Name)
+				if withKubeconfig {
+					dir, _ := cmd.Flags().GetString("kubeconfig-dir")
+					if kubeconfigFile, err = absKubeconfigPath(dir, params.Name); err != nil {
+						return err
+					}
 				}
-				return fmt.Errorf("operation cancelled during spot pool creation")
-			default:
-				// Continue with pool creation
 			}
+			return client.CreateCloudspace(ctx, cloudspace)
+		})
+		if err != nil {
+			return steps.Fail(fmt.Errorf("failed to create cloudspace: %w", err))
+		}
+		trace.track("create cloudspace", phaseStart)
+		steps.Done()
+
+		result := createCloudspaceResult{
+			SpotNodePools:     []*rxtspot.SpotNodePool{},
+			OnDemandNodePools: []*rxtspot.OnDemandNodePool{},
+		}
+		noRollback, _ := cmd.Flags().GetBool("no-rollback")
+		parallelism, _ := cmd.Flags().GetInt("parallelism")
+		// Pools created so far, in creation order, waiting to be verified
+		var created []nodePoolRef
+		// Create node pools in priority order (lower first). Pools with equal priority keep
+		// their order, spot pools before on-demand pools.
+		for _, step := range poolCreationOrder(params) {
+			if step.spot {
+				pool := params.SpotNodePools[step.index]
+				// Check if context was cancelled before each pool creation
+				select {
+				case <-ctx.Done():
+					// Clean up the cloudspace if we're cancelled mid-creation
+					if err := client.GetAPI().DeleteCloudspace(ctx, params.Org, params.Name); err != nil {
+						klog.Warningf("Failed to clean up cloudspace after cancellation: %v", err)
+					}
+					return fmt.Errorf("operation cancelled during spot pool creation")
+				default:
+					// Continue with pool creation
+				}
 
-			// Ensure bid price is properly formatted
-			bidPrice, err := validateBidPrice(pool.BidPrice)
-			if err != nil {
-				return fmt.Errorf("invalid bid price for pool %s: %w", pool.Name, err)
-			}
+				// Ensure bid price is properly formatted
+				bidPrice, err := validateBidPrice(pool.BidPrice)
+				if err != nil {
+					return fmt.Errorf("invalid bid price for pool %s: %w", pool.Name, err)
+				}
 
-			// Validate the bid price
-			bidPrice, err = getBidPrice(bidPrice)
-			if err != nil {
-				return fmt.Errorf("invalid bid price for pool %s: %w", pool.Name, err)
-			}
-			if pool.Name == "" {
-				pool.Name = uuid.NewString()
-			}
+				// Validate the bid price
+				bidPrice, err = getBidPrice(bidPrice)
+				if err != nil {
+					return fmt.Errorf("invalid bid price for pool %s: %w", pool.Name, err)
+				}
+				if pool.Name == "" {
+					pool.Name = uuid.NewString()
+				}
 
-			spotPool := rxtspot.SpotNodePool{
-				Name:        pool.Name,
-				Org:         params.Org,
-				Cloudspace:  params.Name,
-				ServerClass: pool.ServerClass,
-				BidPrice:    bidPrice,
-				Desired:     pool.Desired,
-			}
+				spotPool := rxtspot.SpotNodePool{
+					Name:        pool.Name,
+					Org:         params.Org,
+					Cloudspace:  params.Name,
+					ServerClass: pool.ServerClass,
+					BidPrice:    bidPrice,
+					Desired:     pool.Desired,
+				}
 
-			// Create the spot node pool with context
-			createErr := client.GetAPI().CreateSpotNodePool(ctx, params.Org, spotPool)
-			if createErr != nil {
-				err = client.GetAPI().DeleteCloudspace(context.Background(), params.Org, params.Name)
-				if err != nil {
-					return fmt.Errorf("failed to delete cloudspace %s: %w", params.Name, err)
+				// Create the spot node pool with context
+				steps.Start("Creating spot node pool %s", spotPool.Name)
+				phaseStart = time.Now()
+				createErr := client.GetAPI().CreateSpotNodePool(ctx, params.Org, spotPool)
+				trace.track("create spot pool "+spotPool.Name, phaseStart)
+				if createErr != nil {
+					steps.Fail(createErr)
+					if noRollback {
+						result.FailedNodePools = append(result.FailedNodePools, nodePoolRef{Name: spotPool.Name, Type: "spot", ServerClass: spotPool.ServerClass, Desired: spotPool.Desired}.failed(createErr))
+						continue
+					}
+					err = client.GetAPI().DeleteCloudspace(context.Background(), params.Org, params.Name)
+					if err != nil {
+						return fmt.Errorf("failed to delete cloudspace %s: %w", params.Name, err)
+					}
+					return fmt.Errorf("failed to create spot node pool %s : %w", spotPool.Name, createErr)
 				}
-				return fmt.Errorf("failed to create spot node pool %s : %w", spotPool.Name, createErr)
-			}
 
-			// Verify the pool was created successfully
-			if _, verifyErr := client.GetAPI().GetSpotNodePool(context.Background(), params.Org, spotPool.Name); verifyErr != nil {
-				err = fmt.Errorf("failed to verify creation of spot node pool %s: %w", spotPool.Name, verifyErr)
-				return err
+				created = append(created, nodePoolRef{Name: spotPool.Name, Type: "spot", ServerClass: spotPool.ServerClass, Desired: spotPool.Desired})
+				steps.Done()
+				continue
 			}
-		}
 
-		// Create on-demand node pools if any
-		for _, pool := range params.OnDemandNodePools {
+			pool := params.OnDemandNodePools[step.index]
 			// Check if context was cancelled before each pool creation
 			select {
 			case <-ctx.Done():
@@ -368,8 +833,16 @@ var cloudspacesCreateCmd = &cobra.Command{
 			}
 
 			// Create the on-demand node pool with context
+			steps.Start("Creating on-demand node pool %s", onDemandPool.Name)
+			phaseStart = time.Now()
 			createErr := client.GetAPI().CreateOnDemandNodePool(ctx, params.Org, onDemandPool)
+			trace.track("create on-demand pool "+onDemandPool.Name, phaseStart)
 			if createErr != nil {
+				steps.Fail(createErr)
+				if noRollback {
+					result.FailedNodePools = append(result.FailedNodePools, nodePoolRef{Name: onDemandPool.Name, Type: "ondemand", ServerClass: onDemandPool.ServerClass, Desired: onDemandPool.Desired}.failed(createErr))
+					continue
+				}
 				err = client.GetAPI().DeleteCloudspace(context.Background(), params.Org, params.Name)
 				if err != nil {
 					return fmt.Errorf("failed to delete cloudspace %s: %w", params.Name, err)
@@ -377,19 +850,91 @@ var cloudspacesCreateCmd = &cobra.Command{
 				return fmt.Errorf("failed to create on-demand node pool %s: %w", onDemandPool.Name, createErr)
 			}
 
-			// Verify the pool was created successfully
-			if _, verifyErr := client.GetAPI().GetOnDemandNodePool(context.Background(), params.Org, onDemandPool.Name); verifyErr != nil {
-				return fmt.Errorf("failed to verify creation of on-demand node pool %s: %w", onDemandPool.Name, verifyErr)
+			created = append(created, nodePoolRef{Name: onDemandPool.Name, Type: "ondemand", ServerClass: onDemandPool.ServerClass, Desired: onDemandPool.Desired})
+			steps.Done()
+		}
+
+		// Creation follows the priority order, but the pools can be read back concurrently
+		var verified *verifiedNodePools
+		if !skipVerify && len(created) > 0 {
+			steps.Start("Verifying %d node pool(s)", len(created))
+			phaseStart = time.Now()
+			verified = verifyNodePools(ctx, client, params.Org, created, parallelism)
+			trace.track("verify node pools", phaseStart)
+			if len(verified.failed) > 0 && !noRollback {
+				return steps.Fail(verified.failed[0].err)
+			}
+			if len(verified.failed) > 0 {
+				steps.Fail(fmt.Errorf("%d node pool(s) could not be verified", len(verified.failed)))
+			} else {
+				steps.Done()
 			}
 		}
 
-		cloudspaceGetResponse, err := client.GetAPI().GetCloudspace(context.Background(), params.Org, params.Name)
+		steps.Start("Fetching cloudspace %s", params.Name)
+		phaseStart = time.Now()
+		// Node pool creation changes the cloudspace, so never serve it from the cache
+		client.InvalidateCloudspace(params.Org, params.Name)
+		cloudspaceGetResponse, err := client.GetCloudspace(ctx, params.Org, params.Name)
 		if err != nil {
-			return fmt.Errorf("failed to get cloudspace: %w", err)
+			return steps.Fail(fmt.Errorf("failed to get cloudspace: %w", err))
+		}
+		trace.track("get cloudspace", phaseStart)
+		if verified == nil {
+			// With --skip-verify no pool was read back, so the cloudspace must at least list them all
+			verified = nodePoolsInCloudspace(cloudspaceGetResponse, created)
+			if len(verified.failed) > 0 && !noRollback {
+				return steps.Fail(verified.failed[0].err)
+			}
+		}
+		steps.Done()
+		result.SpotNodePools = append(result.SpotNodePools, verified.spot...)
+		result.OnDemandNodePools = append(result.OnDemandNodePools, verified.onDemand...)
+		for _, f := range verified.failed {
+			result.FailedNodePools = append(result.FailedNodePools, f.pool.failed(f.err))
+		}
+		if wait {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			phaseStart = time.Now()
+			cloudspaceGetResponse, err = waitForCloudspaceReady(ctx, client, params.Org, params.Name, timeout, steps)
+			if err != nil {
+				return err
+			}
+			trace.track("wait for ready", phaseStart)
+		}
+		if withKubeconfig {
+			steps.Start("Saving kubeconfig to %s", kubeconfigFile)
+			phaseStart = time.Now()
+			if err := writeKubeconfig(ctx, client, params.Org, params.Name, kubeconfigFile, false); err != nil {
+				return steps.Fail(err)
+			}
+			trace.track("get kubeconfig", phaseStart)
+			steps.Done()
+			result.KubeconfigPath = kubeconfigFile
+		}
+		result.Cloudspace = cloudspaceGetResponse
+		if len(result.FailedNodePools) > 0 {
+			total := len(params.SpotNodePools) + len(params.OnDemandNodePools)
+			fmt.Fprintf(os.Stderr, "\n%s Created cloudspace '%s' in region '%s', but %d of %d node pools failed:\n",
+				color.YellowString("!"),
+				color.CyanString(cloudspaceGetResponse.Name),
+				color.CyanString(cloudspaceGetResponse.Region),
+				len(result.FailedNodePools), total,
+			)
+			for _, f := range result.FailedNodePools {
+				fmt.Fprintf(os.Stderr, "  %s %s %s: %s\n", color.RedString(ui.CrossMark()), f.Type, f.Name, f.Error)
+			}
+			if err := outputCreateResult(result, printKubeconfigPath); err != nil {
+				return err
+			}
+			return &exitError{
+				code: exitPartialSuccess,
+				err:  fmt.Errorf("cloudspace '%s' created with %d failed node pool(s)", params.Name, len(result.FailedNodePools)),
+			}
 		}
 		// If we got here, everything was successful
-		fmt.Printf("\n%s Successfully created cloudspace '%s' in region '%s'\n",
-			color.GreenString("✓"),
+		fmt.Fprintf(os.Stderr, "\n%s Successfully created cloudspace '%s' in region '%s'\n",
+			color.GreenString(ui.CheckMark()),
 			color.CyanString(cloudspaceGetResponse.Name),
 			color.CyanString(cloudspaceGetResponse.Region),
 		)
@@ -403,12 +948,22 @@ var cloudspacesCreateCmd = &cobra.Command{
 			}
 			return fmt.Errorf("operation cancelled during finalization")
 		default:
-			// Output the created cloudspace details
-			return internal.OutputData(cloudspaceGetResponse, outputFormat)
+			// Output the created cloudspace along with its node pools
+			return outputCreateResult(result, printKubeconfigPath)
 		}
 	},
 }
 
+// outputCreateResult prints the result of cloudspaces create, or only the kubeconfig path
+// with --print-kubeconfig-path so that scripts can capture it
+func outputCreateResult(result createCloudspaceResult, pathOnly bool) error {
+	if pathOnly {
+		fmt.Println(result.KubeconfigPath)
+		return nil
+	}
+	return internal.OutputData(result, outputFormat)
+}
+
 // cloudspacesGetCmd represents the cloudspaces get command
 var cloudspacesGetCmd = &cobra.Command{
 	Use:   "get",
@@ -425,12 +980,9 @@ var cloudspacesGetCmd = &cobra.Command{
 			return fmt.Errorf("failed to get config: %w", err)
 		}
 
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" && cfg != nil && cfg.Org != "" {
-			org = cfg.Org
-		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
@@ -438,18 +990,28 @@ var cloudspacesGetCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize client: %w", err)
 		}
 
-		cloudspace, err := client.GetAPI().GetCloudspace(context.Background(), org, name)
-		if err != nil {
-			if rxtspot.IsNotFound(err) {
-				return fmt.Errorf("cloudspace '%s' not found", name)
+		fetch := func(ctx context.Context) (interface{}, error) {
+			cloudspace, err := client.GetAPI().GetCloudspace(ctx, org, name)
+			if err != nil {
+				if rxtspot.IsNotFound(err) {
+					return nil, fmt.Errorf("cloudspace '%s' not found", name)
+				}
+				return nil, fmt.Errorf("failed to get cloudspace: %w", err)
 			}
-			return fmt.Errorf("failed to get cloudspace: %w", err)
+			return cloudspace, nil
 		}
 
-		// Get output format from flags, default to "json"
-		outputFormat, _ := cmd.Flags().GetString("output")
-		if outputFormat == "" {
-			outputFormat = "json"
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			interval, _ := cmd.Flags().GetDuration("watch-interval")
+			if interval <= 0 {
+				return fmt.Errorf("watch-interval must be positive")
+			}
+			return watchResource(cmd.Context(), interval, fetch)
+		}
+
+		cloudspace, err := fetch(context.Background())
+		if err != nil {
+			return err
 		}
 
 		// Use the OutputData function for all output formats
@@ -465,15 +1027,13 @@ var cloudspacesGetConfigCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		cfg, err := config.GetCLIEssentials(cmd)
-
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
+		if err != nil {
+			return err
 		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 
 		name, _ := cmd.Flags().GetString("name")
@@ -481,32 +1041,168 @@ var cloudspacesGetConfigCmd = &cobra.Command{
 			return fmt.Errorf("name is required")
 		}
 
-		var filePath string
-		fileName, _ := cmd.Flags().GetString("file")
-		if fileName == "" {
-			filePath = filepath.Join(os.Getenv("HOME"), ".kube", name+".yaml")
-		} else {
-			filePath = fileName + "/" + name + ".yaml"
+		merge, _ := cmd.Flags().GetBool("merge")
+		use, _ := cmd.Flags().GetBool("use")
+		if use && !merge {
+			return fmt.Errorf("--use requires --merge")
+		}
+		if merge {
+			if cmd.Flags().Changed("file") || cmd.Flags().Changed("overwrite") {
+				return fmt.Errorf("--merge cannot be combined with --file or --overwrite")
+			}
+			client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+			execCred, _ := cmd.Flags().GetBool("exec-credential")
+			return mergeCloudspaceKubeconfig(cmd.Context(), client, org, name, execCred, use)
 		}
 
-		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		fileName, _ := cmd.Flags().GetString("file")
+		filePath, err := kubeconfigPath(fileName, name)
 		if err != nil {
-			return fmt.Errorf("%w", err)
+			return err
 		}
-		k8sConfig, err := client.GetAPI().GetCloudspaceConfig(context.Background(), org, name)
-		if err != nil {
-			return fmt.Errorf("%w", err)
+
+		// Never clobber an existing kubeconfig unless explicitly allowed
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+		if _, statErr := os.Stat(filePath); statErr == nil && !overwrite {
+			if !canPrompt() {
+				return fmt.Errorf("file %s already exists (use --overwrite to replace it)", filePath)
+			}
+			if !confirmYesNo("File '%s' already exists. Overwrite? (y/N): ", filePath) {
+				return nil
+			}
 		}
 
-		err = os.WriteFile(filePath, []byte(k8sConfig), 0644)
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
 		if err != nil {
-			return fmt.Errorf("failed to write config to file: %w", err)
+			return fmt.Errorf("%w", err)
 		}
-		fmt.Fprintf(os.Stdout, "Config has been saved to %s successfully\n", filePath)
+		execCred, _ := cmd.Flags().GetBool("exec-credential")
+		if err := writeKubeconfig(context.Background(), client, org, name, filePath, execCred); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Config has been saved to %s successfully\n", filePath)
 		return nil
 	},
 }
 
+// kubeconfigPath returns where the kubeconfig of cloudspace name is written: <dir>/<name>.yaml,
+// or ~/.kube/<name>.yaml when dir is empty
+func kubeconfigPath(dir, name string) (string, error) {
+	dir, err := config.ExpandPath(dir)
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		return filepath.Join(os.Getenv("HOME"), ".kube", name+".yaml"), nil
+	}
+	return dir + "/" + name + ".yaml", nil
+}
+
+// absKubeconfigPath is kubeconfigPath made absolute, so that the reported path stays valid
+// when used from another directory
+func absKubeconfigPath(dir, name string) (string, error) {
+	path, err := kubeconfigPath(dir, name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(path)
+}
+
+// writeKubeconfig downloads the kubeconfig of a cloudspace to path. With execCred its users
+// fetch tokens through 'spotctl auth exec-credential' instead of embedding a static one.
+func writeKubeconfig(ctx context.Context, client *internal.Client, org, name, path string, execCred bool) error {
+	data, err := fetchKubeconfig(ctx, client, org, name, execCred)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config to file: %w", err)
+	}
+	return nil
+}
+
+// fetchKubeconfig downloads the kubeconfig of a cloudspace, rewritten for the exec credential
+// plugin when execCred is set
+func fetchKubeconfig(ctx context.Context, client *internal.Client, org, name string, execCred bool) ([]byte, error) {
+	k8sConfig, err := client.GetAPI().GetCloudspaceConfig(ctx, org, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	data := []byte(k8sConfig)
+	if execCred {
+		if data, err = withExecCredential(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// nodePoolSummary replaces the node pool details of a cloudspace in --compact-pools output
+type nodePoolSummary struct {
+	Spot         int `json:"spot" yaml:"spot"`
+	OnDemand     int `json:"onDemand" yaml:"onDemand"`
+	TotalDesired int `json:"totalDesired" yaml:"totalDesired"`
+}
+
+// toGenericMaps converts a list of API objects into their JSON representation as maps
+func toGenericMaps(data interface{}) ([]map[string]interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data: %w", err)
+	}
+	items := []map[string]interface{}{}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+	return items, nil
+}
+
+// summarizeNodePools counts the node pools embedded in a JSON-decoded cloudspace.
+// When remove is set the node pool fields are deleted from the map.
+func summarizeNodePools(cloudspace map[string]interface{}, remove bool) nodePoolSummary {
+	var summary nodePoolSummary
+	for key, value := range cloudspace {
+		lower := strings.ToLower(key)
+		if !strings.Contains(lower, "pool") {
+			continue
+		}
+		pools, _ := value.([]interface{})
+		switch {
+		case strings.Contains(lower, "ondemand"):
+			summary.OnDemand += len(pools)
+		case strings.Contains(lower, "spot"):
+			summary.Spot += len(pools)
+		default:
+			continue
+		}
+		for _, p := range pools {
+			pool, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for k, v := range pool {
+				if desired, ok := v.(float64); ok && strings.EqualFold(k, "desired") {
+					summary.TotalDesired += int(desired)
+				}
+			}
+		}
+		if remove {
+			delete(cloudspace, key)
+		}
+	}
+	return summary
+}
+
+// compactNodePools replaces the node pool slices of each cloudspace with a summary
+func compactNodePools(cloudspaces []map[string]interface{}) {
+	for _, cs := range cloudspaces {
+		cs["nodePools"] = summarizeNodePools(cs, true)
+	}
+}
+
 // getBidPrice parses and validates the minimum bid price
 func getBidPrice(priceStr string) (string, error) {
 	if priceStr == "" {
@@ -553,10 +1249,11 @@ func getBidPrice(priceStr string) (string, error) {
 }
 
 // collectInteractiveInput gathers all required parameters interactively using BubbleTea
-func collectInteractiveInput(client *internal.Client, cfg *config.SpotConfig) (*createCloudspaceParams, error) {
+func collectInteractiveInput(ctx context.Context, client *internal.Client, cfg *config.SpotConfig, defaults createCloudspaceParams, maxBidMultiple float64) (*createCloudspaceParams, error) {
 	fmt.Println("\nStarting interactive cloudspace creation...")
 	// Initialize the interactive model (holds params and step functions)
-	model := initInteractiveModel(client, cfg)
+	model := initInteractiveModel(ctx, client, cfg, defaults)
+	model.maxBidMultiple = maxBidMultiple
 
 	// Execute each interactive step sequentially. Each step handles its own prompt.
 	for _, step := range model.steps {
@@ -580,53 +1277,139 @@ func collectInteractiveInput(client *internal.Client, cfg *config.SpotConfig) (*
 	return &cp, nil
 }
 
-// validateCreateParams validates the provided parameters
-func validateCreateParams(params *createCloudspaceParams, interactive bool) error {
-	// Skip validation in interactive mode as we'll collect all required parameters
-	if interactive {
-		return nil
+// validationErrors collects every problem found while validating create parameters
+type validationErrors []error
+
+// Error lists each problem on its own line
+func (v validationErrors) Error() string {
+	if len(v) == 1 {
+		return v[0].Error()
 	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "found %d problems:", len(v))
+	for _, err := range v {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap exposes the individual problems to errors.Is and errors.As
+func (v validationErrors) Unwrap() []error {
+	return v
+}
+
+// poolLabel names a node pool in validation messages, falling back to its position when the
+// pool has no name yet
+func poolLabel(kind, name string, index int) string {
+	if name == "" {
+		return fmt.Sprintf("%s node pool #%d", kind, index+1)
+	}
+	return fmt.Sprintf("%s node pool %s", kind, name)
+}
+
+// validateCreateParams validates the provided parameters and reports every problem at once.
+// Values collected by the wizard are checked too, since a step that was cut short may leave
+// fields unset.
+func validateCreateParams(params *createCloudspaceParams, interactive bool) error {
+	var errs validationErrors
 
-	// Non-interactive mode validations
 	if params.Name == "" {
-		return fmt.Errorf("name is required")
+		if interactive {
+			errs = append(errs, fmt.Errorf("name is required, the wizard ended before a name was entered"))
+		} else {
+			errs = append(errs, fmt.Errorf("name is required"))
+		}
 	}
 
 	if params.Region == "" {
-		return fmt.Errorf("region is required")
-
+		if interactive {
+			errs = append(errs, fmt.Errorf("region is required, the wizard ended before a region was selected"))
+		} else {
+			errs = append(errs, fmt.Errorf("region is required"))
+		}
+	} else if !isValidRegion(params.Region) {
+		errs = append(errs, invalidRegionError(params.Region))
 	}
 
-	if !isValidRegion(params.Region) {
-		return fmt.Errorf("region %s is not valid. Available regions: %s, %s, %s, %s, %s, %s, %s, %s", params.Region, US_CENTRAL_ORD_1, HKG_HKG_1, AUS_SYD_1, UK_LON_1, US_EAST_IAD_1, US_CENTRAL_DFW_1, US_CENTRAL_DFW_2, US_WEST_SJC_1)
+	if params.PreemptionWebhookURL != "" {
+		u, err := url.ParseRequestURI(params.PreemptionWebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid preemption webhook URL '%s': must be an absolute http or https URL", params.PreemptionWebhookURL))
+		}
 	}
 
-	// Require at least one node pool in non-interactive mode
+	// Require at least one node pool
 	if len(params.SpotNodePools) == 0 && len(params.OnDemandNodePools) == 0 {
-		return fmt.Errorf("at least one node pool is required when using flags (use --spot-nodepool or --ondemand-nodepool)")
+		if interactive {
+			errs = append(errs, fmt.Errorf("at least one node pool is required, add a spot or on-demand node pool in the wizard"))
+		} else {
+			errs = append(errs, fmt.Errorf("at least one node pool is required when using flags (use --spot-nodepool or --ondemand-nodepool)"))
+		}
 	}
 
 	// Validate spot node pools' bid prices
 	for i, pool := range params.SpotNodePools {
+		label := poolLabel("spot", pool.Name, i)
 		if pool.BidPrice == "" {
-			return fmt.Errorf("bid price is required for spot node pool %s", pool.Name)
+			errs = append(errs, fmt.Errorf("bid price is required for %s", label))
+		} else if bid, err := validateBidPrice(pool.BidPrice); err != nil {
+			errs = append(errs, fmt.Errorf("invalid bid price for %s: %w", label, err))
+		} else {
+			params.SpotNodePools[i].BidPrice = bid
 		}
-		_, err := validateBidPrice(pool.BidPrice)
-		if err != nil {
-			return fmt.Errorf("invalid bid price for pool %s: %w", pool.Name, err)
+		if pool.Desired < 0 {
+			errs = append(errs, fmt.Errorf("desired number of nodes cannot be negative for %s", label))
 		}
-		params.SpotNodePools[i].BidPrice, _ = validateBidPrice(pool.BidPrice)
 	}
 
 	for i, pool := range params.OnDemandNodePools {
 		if pool.Desired <= 0 {
-			return fmt.Errorf("desired number of nodes must be greater than 0 for on-demand node pool %s", pool.Name)
+			errs = append(errs, fmt.Errorf("desired number of nodes must be greater than 0 for %s", poolLabel("on-demand", pool.Name, i)))
 		}
-		params.OnDemandNodePools[i].Desired = pool.Desired
+	}
+
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
 
+// envReference matches "$$" and ${VAR} or ${VAR:-default} in a --config file. Bare $VAR is
+// not expanded so that prices such as "$0.08" need no escaping.
+var envReference = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnvReferences replaces ${VAR} and ${VAR:-default} with values from lookup. As in the
+// shell, the default is used when VAR is unset or empty; a reference without a default to an
+// unset variable is an error. "$$" produces a literal "$".
+func expandEnvReferences(content []byte, lookup func(string) (string, bool)) ([]byte, error) {
+	var undefined []string
+	seen := map[string]bool{}
+	expanded := envReference.ReplaceAllFunc(content, func(ref []byte) []byte {
+		if string(ref) == "$$" {
+			return []byte("$")
+		}
+		m := envReference.FindSubmatch(ref)
+		name, hasDefault := string(m[1]), m[2] != nil
+		value, ok := lookup(name)
+		switch {
+		case hasDefault && value == "":
+			return m[3]
+		case !ok:
+			if !seen[name] {
+				seen[name] = true
+				undefined = append(undefined, name)
+			}
+			return ref
+		}
+		return []byte(value)
+	})
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("undefined environment variable(s): %s (set them or use ${VAR:-default})", strings.Join(undefined, ", "))
+	}
+	return expanded, nil
+}
+
 // loadParamsFromFlags loads parameters from command line flags and config file if provided
 func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 	params := &createCloudspaceParams{}
@@ -634,18 +1417,24 @@ func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 	// First check if config file is provided
 	configPath, _ := cmd.Flags().GetString("config")
 	if configPath != "" {
+		configPath, err := config.ExpandPath(configPath)
+		if err != nil {
+			return nil, err
+		}
 		// Read the entire file content
 		content, err := os.ReadFile(configPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read config file: %w", err)
 		}
+		if expand, _ := cmd.Flags().GetBool("expand-env"); expand {
+			if content, err = expandEnvReferences(content, os.LookupEnv); err != nil {
+				return nil, fmt.Errorf("failed to expand config file: %w", err)
+			}
+		}
 
 		// Parse based on file extension
-		var fullConfig struct {
-			CloudSpace        rxtspot.CloudSpace         `json:"cloudspace" yaml:"cloudspace"`
-			SpotNodePools     []rxtspot.SpotNodePool     `json:"spotnodepools" yaml:"spotnodepools"`
-			OnDemandNodePools []rxtspot.OnDemandNodePool `json:"ondemandnodepools" yaml:"ondemandnodepools"`
-		}
+		var fullConfig cloudspaceManifest
+		var priorities poolPriorities
 
 		ext := strings.ToLower(filepath.Ext(configPath))
 		switch ext {
@@ -653,10 +1442,16 @@ func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 			if err := yaml.Unmarshal(content, &fullConfig); err != nil {
 				return nil, fmt.Errorf("failed to unmarshal YAML config: %w", err)
 			}
+			if err := yaml.Unmarshal(content, &priorities); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal node pool priorities: %w", err)
+			}
 		case ".json":
 			if err := json.Unmarshal(content, &fullConfig); err != nil {
 				return nil, fmt.Errorf("failed to unmarshal JSON config: %w", err)
 			}
+			if err := json.Unmarshal(content, &priorities); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal node pool priorities: %w", err)
+			}
 		default:
 			return nil, fmt.Errorf("unsupported config file format: %s (must be .yaml, .yml, or .json)", ext)
 		}
@@ -668,6 +1463,12 @@ func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 		params.CNI = fullConfig.CloudSpace.CNI
 		params.SpotNodePools = fullConfig.SpotNodePools
 		params.OnDemandNodePools = fullConfig.OnDemandNodePools
+		for _, p := range priorities.SpotNodePools {
+			params.SpotPriorities = append(params.SpotPriorities, p.Priority)
+		}
+		for _, p := range priorities.OnDemandNodePools {
+			params.OnDemandPriorities = append(params.OnDemandPriorities, p.Priority)
+		}
 		return params, nil
 	}
 
@@ -691,9 +1492,16 @@ func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 			continue
 		}
 
-		desired, _ := strconv.Atoi(poolParams["desired"])
-		if desired <= 0 {
-			desired = 1 // Default to 1 if not specified or invalid
+		desired := 1 // Default to 1 if not specified
+		if poolParams["desired"] != "" {
+			if desired, err = parseDesired(poolParams["desired"], false); err != nil {
+				return nil, fmt.Errorf("invalid spot nodepool %q: %w", poolStr, err)
+			}
+		}
+
+		priority, err := parsePoolPriority(poolParams["priority"])
+		if err != nil {
+			return nil, err
 		}
 
 		spotPool := rxtspot.SpotNodePool{
@@ -705,6 +1513,7 @@ func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 			Desired:     desired,
 		}
 		params.SpotNodePools = append(params.SpotNodePools, spotPool)
+		params.SpotPriorities = append(params.SpotPriorities, priority)
 	}
 
 	for _, poolStr := range onDemandPools {
@@ -714,9 +1523,16 @@ func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 			continue
 		}
 
-		desired, _ := strconv.Atoi(poolParams["desired"])
-		if desired <= 0 {
-			desired = 1 // Default to 1 if not specified or invalid
+		desired := 1 // Default to 1 if not specified
+		if poolParams["desired"] != "" {
+			if desired, err = parseDesired(poolParams["desired"], false); err != nil {
+				return nil, fmt.Errorf("invalid on-demand nodepool %q: %w", poolStr, err)
+			}
+		}
+
+		priority, err := parsePoolPriority(poolParams["priority"])
+		if err != nil {
+			return nil, err
 		}
 
 		onDemandPool := rxtspot.OnDemandNodePool{
@@ -727,6 +1543,7 @@ func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 			Desired:     desired,
 		}
 		params.OnDemandNodePools = append(params.OnDemandNodePools, onDemandPool)
+		params.OnDemandPriorities = append(params.OnDemandPriorities, priority)
 	}
 
 	// If we got here with no node pools and no config file, that's an error
@@ -737,12 +1554,54 @@ func loadParamsFromFlags(cmd *cobra.Command) (*createCloudspaceParams, error) {
 	return params, nil
 }
 
+// parsePoolPriority parses the optional priority key of a --spot-nodepool or --ondemand-nodepool value
+func parsePoolPriority(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	priority, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid node pool priority %q: must be an integer", value)
+	}
+	return priority, nil
+}
+
+// generatedNameAttempts bounds the retries when a generated name is already taken
+const generatedNameAttempts = 5
+
+// generateCloudspaceName appends a short random suffix to prefix, kubectl generateName style,
+// and returns the first candidate that is not already in use
+func generateCloudspaceName(ctx context.Context, client *internal.Client, org, prefix string) (string, error) {
+	for i := 0; i < generatedNameAttempts; i++ {
+		name := prefix + uuid.NewString()[:5]
+		err := client.CheckCloudspace(ctx, org, name)
+		if rxtspot.IsNotFound(err) {
+			return name, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check whether cloudspace %s exists: %w", name, err)
+		}
+	}
+	return "", fmt.Errorf("could not find an unused name with prefix %q after %d attempts", prefix, generatedNameAttempts)
+}
+
 // isInteractiveMode checks if we should run in interactive mode
-// Interactive mode should only be used when no flags are provided at all
-func isInteractiveMode(cmd *cobra.Command) bool {
+// Interactive mode is used when --interactive is set or when no flags are provided at all
+func isInteractiveMode(cmd *cobra.Command) (bool, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	if forced, _ := cmd.Flags().GetBool("interactive"); forced {
+		if configPath != "" {
+			return false, fmt.Errorf("--interactive cannot be combined with --config")
+		}
+		if !canPrompt() {
+			return false, fmt.Errorf("--interactive requires a terminal and cannot be combined with --no-input")
+		}
+		return true, nil
+	}
+
 	// If --config flag is provided, never use interactive mode
-	if configPath, _ := cmd.Flags().GetString("config"); configPath != "" {
-		return false
+	if configPath != "" {
+		return false, nil
 	}
 
 	// Check if any flags were provided
@@ -752,7 +1611,26 @@ func isInteractiveMode(cmd *cobra.Command) bool {
 	})
 
 	// If any flags were provided, don't use interactive mode
-	return len(flagSet) == 0
+	return len(flagSet) == 0, nil
+}
+
+// wizardDefaultsFromFlags returns the values the wizard starts from: the flag values, with
+// org and region falling back to the CLI configuration
+func wizardDefaultsFromFlags(cmd *cobra.Command, cfg *config.SpotConfig) createCloudspaceParams {
+	var params createCloudspaceParams
+	params.Name, _ = cmd.Flags().GetString("name")
+	params.Org, _ = cmd.Flags().GetString("org")
+	params.Region, _ = cmd.Flags().GetString("region")
+	params.KubernetesVersion, _ = cmd.Flags().GetString("kubernetes-version")
+	params.CNI, _ = cmd.Flags().GetString("cni")
+	params.PreemptionWebhookURL, _ = cmd.Flags().GetString("preemption-webhook-url")
+	if params.Org == "" {
+		params.Org = configDefault("org", cfg.Org)
+	}
+	if params.Region == "" {
+		params.Region = configDefault("region", cfg.Region)
+	}
+	return params
 }
 
 // validateBidPrice validates and formats a bid price string to ensure it has up to 3 decimal places
@@ -789,6 +1667,43 @@ func validateBidPrice(bidPrice string) (string, error) {
 	return formatted, nil
 }
 
+// getMinBidPrices returns the minimum bid price of every server class in a region, keyed by
+// class name. Classes without pricing are left out.
+func getMinBidPrices(ctx context.Context, client *internal.Client, region string) (map[string]string, error) {
+	prices, err := client.RegionPricing(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	minBids := make(map[string]string, len(prices))
+	for name, price := range prices {
+		if minBid := price.EffectiveMinBid(); minBid > 0 {
+			minBids[name] = strconv.FormatFloat(minBid, 'f', -1, 64)
+		}
+	}
+	return minBids, nil
+}
+
+// raiseBidToMinimum returns bid raised to minBid plus buffer when it is below minBid.
+// The returned bool reports whether the bid was raised.
+func raiseBidToMinimum(bid, minBid string, buffer float64) (string, bool, error) {
+	bidVal, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(bid, "$")), 64)
+	if err != nil {
+		return "", false, fmt.Errorf("bid price must be a valid number")
+	}
+	minVal, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(minBid, "$")), 64)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid minimum bid price %q", minBid)
+	}
+	if bidVal >= minVal {
+		return bid, false, nil
+	}
+	adjusted, err := validateBidPrice(strconv.FormatFloat(minVal+buffer, 'f', -1, 64))
+	if err != nil {
+		return "", false, err
+	}
+	return adjusted, true, nil
+}
+
 // parseNodepoolParams parses nodepool parameters in format key1=value1,key2=value2
 func parseNodepoolParams(params string) (map[string]string, error) {
 	if params == "" {
@@ -807,6 +1722,24 @@ func parseNodepoolParams(params string) (map[string]string, error) {
 	return result, nil
 }
 
+// validRegions lists every region accepted by isValidRegion
+var validRegions = []string{US_CENTRAL_ORD_1, HKG_HKG_1, AUS_SYD_1, UK_LON_1, US_EAST_IAD_1, US_CENTRAL_DFW_1, US_CENTRAL_DFW_2, US_WEST_SJC_1}
+
+// promptForValidRegion lets the user pick one of the valid regions
+func promptForValidRegion(ctx context.Context) (string, error) {
+	fmt.Printf("%s Select a region:\n", color.GreenString("?"))
+	m, err := internal.RunProgram(ctx, ui.NewSelectModel(validRegions))
+	if err != nil {
+		return "", err
+	}
+	sm, ok := m.(ui.SelectModel)
+	if !ok || sm.Cancelled() || sm.Selected() == "" {
+		return "", context.Canceled
+	}
+	fmt.Printf("%s Select a region: %s\n", color.GreenString("?"), color.CyanString(sm.Selected()))
+	return sm.Selected(), nil
+}
+
 func isValidRegion(region string) bool {
 
 	switch region {
@@ -817,14 +1750,18 @@ func isValidRegion(region string) bool {
 	}
 }
 
-func initInteractiveModel(client *internal.Client, cfg *config.SpotConfig) *interactiveModel {
+func initInteractiveModel(ctx context.Context, client *internal.Client, cfg *config.SpotConfig, defaults createCloudspaceParams) *interactiveModel {
 	m := &interactiveModel{
+		ctx:    ctx,
 		client: client,
 		cfg:    cfg,
-		params: createCloudspaceParams{
-			KubernetesVersion: "1.31.1",
-			CNI:               "calico",
-		},
+		params: defaults,
+	}
+	if m.params.KubernetesVersion == "" {
+		m.params.KubernetesVersion = "1.31.1"
+	}
+	if m.params.CNI == "" {
+		m.params.CNI = "calico"
 	}
 
 	// Define the steps of our interactive flow
@@ -834,6 +1771,7 @@ func initInteractiveModel(client *internal.Client, cfg *config.SpotConfig) *inte
 		m.stepSelectKubernetesVersion,
 		m.stepSelectCNI,
 		m.stepAddNodePools,
+		m.stepReviewNodePools,
 		m.stepSummaryAndConfirm,
 	}
 
@@ -874,21 +1812,81 @@ func (m *interactiveModel) View() string {
 	return ""
 }
 
+// promptText asks for free-form input until validate accepts it. Steps use it as a
+// fallback when their selection list cannot be fetched or shown.
+func (m *interactiveModel) promptText(message, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		value, err := internal.PromptForString(m.ctx, message, defaultValue)
+		if err != nil {
+			return "", err
+		}
+		value = strings.TrimSpace(value)
+		if err := validate(value); err != nil {
+			fmt.Printf("%v. Please try again.\n", err)
+			continue
+		}
+		return value, nil
+	}
+}
+
+// handlePromptError marks the wizard cancelled when the user aborted a prompt and
+// otherwise wraps err with msg
+func (m *interactiveModel) handlePromptError(msg string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		m.cancelled = true
+		return nil
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// requireNonEmpty returns a validator rejecting empty input
+func requireNonEmpty(what string) func(string) error {
+	return func(value string) error {
+		if value == "" {
+			return fmt.Errorf("%s cannot be empty", what)
+		}
+		return nil
+	}
+}
+
+// requireOneOf returns a validator accepting only the given options
+func requireOneOf(what string, options []string) func(string) error {
+	return func(value string) error {
+		for _, o := range options {
+			if value == o {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of: %s", what, strings.Join(options, ", "))
+	}
+}
+
+// requireServerClassName validates a manually entered server class name
+func requireServerClassName(value string) error {
+	if value == "" {
+		return fmt.Errorf("server class cannot be empty")
+	}
+	if strings.ContainsAny(value, " \t") {
+		return fmt.Errorf("server class must not contain spaces")
+	}
+	return nil
+}
+
 func (m *interactiveModel) stepSelectRegion() error {
 	fmt.Println("Fetching available regions...")
 
 	// Try to get available regions
-	regions, err := m.client.GetAPI().ListRegions(context.Background())
+	fetchCtx, cancel := internal.PromptFetchContext(m.ctx)
+	regions, err := m.client.GetAPI().ListRegions(fetchCtx)
+	cancel()
 	if err != nil || len(regions) == 0 {
+		if err != nil && m.ctx.Err() == nil {
+			fmt.Printf("Could not list regions: %v\n", internal.PromptFetchError(fetchCtx, "listing regions", err))
+		}
 		// Fallback to manual input if listing regions is not permitted or empty
-		region, ierr := internal.PromptForString("Enter region (e.g., ord, iad, dfw)", m.params.Region)
+		region, ierr := m.promptText("Enter region (e.g., us-central-ord-1)", m.params.Region, requireNonEmpty("Region"))
 		if ierr != nil {
-			return fmt.Errorf("region input failed: %w", ierr)
-		}
-		region = strings.TrimSpace(region)
-		if region == "" {
-			fmt.Println("Region cannot be empty. Please enter a valid region.")
-			return m.stepSelectRegion()
+			return m.handlePromptError("region input failed", ierr)
 		}
 		m.params.Region = region
 		return nil
@@ -901,8 +1899,7 @@ func (m *interactiveModel) stepSelectRegion() error {
 	}
 
 	// Create and run the selection prompt
-	p := tea.NewProgram(ui.NewSelectModel(regionOptions))
-	m2, err := p.Run()
+	m2, err := internal.RunProgram(m.ctx, ui.NewSelectModel(regionOptions).WithDefault(m.params.Region))
 	if err != nil {
 		return fmt.Errorf("region selection failed: %w", err)
 	}
@@ -928,8 +1925,7 @@ func (m *interactiveModel) stepEnterName() error {
 	fmt.Printf("\n%s Enter a name for your cloudspace:\n", color.GreenString("?"))
 	for {
 		// Create and run the input prompt
-		p := tea.NewProgram(ui.NewInputModel("Enter cloudspace name", "", false))
-		m2, err := p.Run()
+		m2, err := internal.RunProgram(m.ctx, ui.NewInputModel("Enter cloudspace name", m.params.Name, false))
 		if err != nil {
 			return fmt.Errorf("name input failed: %w", err)
 		}
@@ -959,10 +1955,18 @@ func (m *interactiveModel) stepSelectKubernetesVersion() error {
 	versions := []string{"1.31.1", "1.30.10", "1.29.6"}
 
 	// Create and run the selection prompt
-	p := tea.NewProgram(ui.NewSelectModel(versions))
-	m2, err := p.Run()
+	m2, err := internal.RunProgram(m.ctx, ui.NewSelectModel(versions).WithDefault(m.params.KubernetesVersion))
 	if err != nil {
-		return fmt.Errorf("kubernetes version selection failed: %w", err)
+		if m.ctx.Err() != nil {
+			return fmt.Errorf("kubernetes version selection failed: %w", err)
+		}
+		// Fall back to manual entry if the selection list cannot be shown
+		version, ierr := m.promptText("Enter Kubernetes version ("+strings.Join(versions, ", ")+")", m.params.KubernetesVersion, requireOneOf("Kubernetes version", versions))
+		if ierr != nil {
+			return m.handlePromptError("kubernetes version input failed", ierr)
+		}
+		m.params.KubernetesVersion = version
+		return nil
 	}
 
 	if sm, ok := m2.(ui.SelectModel); ok {
@@ -988,10 +1992,18 @@ func (m *interactiveModel) stepSelectCNI() error {
 	cniOptions := []string{"calico", "cilium", "bring your own CNI"}
 
 	// Create and run the selection prompt
-	p := tea.NewProgram(ui.NewSelectModel(cniOptions))
-	m2, err := p.Run()
+	m2, err := internal.RunProgram(m.ctx, ui.NewSelectModel(cniOptions).WithDefault(m.params.CNI))
 	if err != nil {
-		return fmt.Errorf("cni selection failed: %w", err)
+		if m.ctx.Err() != nil {
+			return fmt.Errorf("cni selection failed: %w", err)
+		}
+		// Fall back to manual entry if the selection list cannot be shown
+		cni, ierr := m.promptText("Enter CNI plugin ("+strings.Join(cniOptions, ", ")+")", m.params.CNI, requireOneOf("CNI", cniOptions))
+		if ierr != nil {
+			return m.handlePromptError("cni input failed", ierr)
+		}
+		m.params.CNI = cni
+		return nil
 	}
 
 	if sm, ok := m2.(ui.SelectModel); ok {
@@ -1014,7 +2026,7 @@ func (m *interactiveModel) stepSelectCNI() error {
 func (m *interactiveModel) stepAddNodePools() error {
 	for {
 		// Ask pool type
-		poolType, err := m.client.PromptForPoolType()
+		poolType, err := m.client.PromptForPoolType(m.ctx)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				m.cancelled = true
@@ -1031,19 +2043,27 @@ func (m *interactiveModel) stepAddNodePools() error {
 			onDemandPrice string
 		)
 		if strings.EqualFold(poolType, "Spot") {
-			sc, minBid, _, err := m.client.PromptForServerClassWithBidPrice(context.Background(), m.params.Region, "spot")
+			sc, minBid, odPrice, err := m.client.PromptForServerClassWithBidPrice(m.ctx, m.params.Region, "spot")
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					m.cancelled = true
 					return nil
 				}
-				return fmt.Errorf("failed to select server class: %w", err)
+				// Server classes could not be listed; ask for the name instead
+				fmt.Printf("Could not list server classes: %v\n", err)
+				sc, err = m.promptText("Enter server class (e.g., gp.vs1.medium-ord)", "", requireServerClassName)
+				if err != nil {
+					return m.handlePromptError("server class input failed", err)
+				}
+				minBid = ""
+				odPrice = ""
 			}
 			serverClass = sc
 			minBidPrice = minBid
+			onDemandPrice = odPrice
 
 			// Get desired nodes
-			desiredStr, err := m.client.PromptForNodeCount("spot")
+			desiredStr, err := m.client.PromptForNodeCount(m.ctx, "spot")
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					m.cancelled = true
@@ -1051,15 +2071,18 @@ func (m *interactiveModel) stepAddNodePools() error {
 				}
 				return fmt.Errorf("failed to get node count: %w", err)
 			}
-			desired, err := strconv.Atoi(strings.TrimSpace(desiredStr))
-			if err != nil || desired < 1 {
+			desired, err := parseDesired(desiredStr, false)
+			if err != nil {
 				fmt.Println("Please enter a valid number >= 1.")
 				continue
 			}
 
 			// Get bid price
 			bidMsg := fmt.Sprintf("Enter your maximum bid price (minimum: $%s)", minBidPrice)
-			bidPrice, err := m.client.PromptForBidPrice(bidMsg, minBidPrice)
+			if minBidPrice == "" {
+				bidMsg = "Enter your maximum bid price"
+			}
+			bidPrice, err := m.client.PromptForBidPrice(m.ctx, bidMsg, minBidPrice)
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					m.cancelled = true
@@ -1067,12 +2090,14 @@ func (m *interactiveModel) stepAddNodePools() error {
 				}
 				return fmt.Errorf("failed to get bid price: %w", err)
 			}
-			bidPrice, err = validateBidPrice(bidPrice)
+			bidPrice, ok, err := m.checkBid(bidPrice, minBidPrice, onDemandPrice)
 			if err != nil {
-				fmt.Printf("Invalid bid price: %v\n", err)
+				return err
+			}
+			if !ok {
 				continue
 			}
-			fmt.Printf("%s Enter your maximum bid price (minimum: $%s) %s\n", color.GreenString("?"), minBidPrice, color.CyanString(bidPrice))
+			fmt.Printf("%s %s %s\n", color.GreenString("?"), bidMsg, color.CyanString(bidPrice))
 
 			// Add spot pool
 			m.params.SpotNodePools = append(m.params.SpotNodePools, rxtspot.SpotNodePool{
@@ -1082,19 +2107,25 @@ func (m *interactiveModel) stepAddNodePools() error {
 				Desired:     desired,
 			})
 		} else { // On-Demand
-			sc, _, odPrice, err := m.client.PromptForServerClassWithBidPrice(context.Background(), m.params.Region, "ondemand")
+			sc, _, odPrice, err := m.client.PromptForServerClassWithBidPrice(m.ctx, m.params.Region, "ondemand")
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					m.cancelled = true
 					return nil
 				}
-				return fmt.Errorf("failed to select server class: %w", err)
+				// Server classes could not be listed; ask for the name instead
+				fmt.Printf("Could not list server classes: %v\n", err)
+				sc, err = m.promptText("Enter server class (e.g., gp.vs1.medium-ord)", "", requireServerClassName)
+				if err != nil {
+					return m.handlePromptError("server class input failed", err)
+				}
+				odPrice = ""
 			}
 			serverClass = sc
 			onDemandPrice = odPrice
 
 			// Get desired nodes
-			desiredStr, err := m.client.PromptForNodeCount("on-demand")
+			desiredStr, err := m.client.PromptForNodeCount(m.ctx, "on-demand")
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					m.cancelled = true
@@ -1102,8 +2133,8 @@ func (m *interactiveModel) stepAddNodePools() error {
 				}
 				return fmt.Errorf("failed to get node count: %w", err)
 			}
-			desired, err := strconv.Atoi(strings.TrimSpace(desiredStr))
-			if err != nil || desired < 1 {
+			desired, err := parseDesired(desiredStr, false)
+			if err != nil {
 				fmt.Println("Please enter a valid number >= 1.")
 				continue
 			}
@@ -1118,7 +2149,7 @@ func (m *interactiveModel) stepAddNodePools() error {
 		}
 
 		// Ask to add another node pool
-		more, err := internal.Confirm("Add another node pool?", false)
+		more, err := internal.Confirm(m.ctx, "Add another node pool?", false)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				m.cancelled = true
@@ -1133,31 +2164,75 @@ func (m *interactiveModel) stepAddNodePools() error {
 	return nil
 }
 
+// checkBid validates a wizard bid against the minimum and on-demand prices of its server class,
+// either of which may be empty when unknown. It returns the bid to use, or false when the user
+// has to enter another one.
+func (m *interactiveModel) checkBid(bidPrice, minBidPrice, onDemandPrice string) (string, bool, error) {
+	bidPrice, err := validateBidPrice(bidPrice)
+	if err != nil {
+		fmt.Printf("Invalid bid price: %v\n", err)
+		return "", false, nil
+	}
+	if adjusted, raised, err := raiseBidToMinimum(bidPrice, minBidPrice, 0); err == nil && raised {
+		ok, err := internal.Confirm(m.ctx, fmt.Sprintf("Bid $%s is below the minimum of $%s. Raise it to $%s?", bidPrice, minBidPrice, adjusted), true)
+		if err != nil {
+			return "", false, fmt.Errorf("confirmation failed: %w", err)
+		}
+		if !ok {
+			fmt.Printf("Bid price must be at least $%s.\n", minBidPrice)
+			return "", false, nil
+		}
+		bidPrice = adjusted
+	}
+	// Guard against fat-fingered bids: above the on-demand price spot saves nothing
+	if od := bidValue(onDemandPrice); od > 0 {
+		bid := bidValue(bidPrice)
+		odStr := strconv.FormatFloat(od, 'f', -1, 64)
+		if m.maxBidMultiple > 0 && bid > od*m.maxBidMultiple {
+			fmt.Printf("Bid $%s is more than %g times the on-demand price of $%s. Please enter a lower bid.\n", bidPrice, m.maxBidMultiple, odStr)
+			return "", false, nil
+		}
+		if bid > od {
+			ok, err := internal.Confirm(m.ctx, fmt.Sprintf("Bid $%s is above the on-demand price of $%s, so an on-demand pool would cost less. Cap it at $%s?", bidPrice, odStr, odStr), true)
+			if err != nil {
+				return "", false, fmt.Errorf("confirmation failed: %w", err)
+			}
+			if ok {
+				if bidPrice, err = validateBidPrice(odStr); err != nil {
+					return "", false, err
+				}
+			}
+		}
+	}
+	return bidPrice, true, nil
+}
+
 func (m *interactiveModel) stepSummaryAndConfirm() error {
 	// Summary header
 	fmt.Println("\nCloudspace Configuration:")
+	bullet := ui.Bullet()
 	fmt.Printf(`
 Cloudspace Configuration:
-• %-20s %s
-• %-20s %s
-• %-20s %s
-• %-20s %s
+%s %-20s %s
+%s %-20s %s
+%s %-20s %s
+%s %-20s %s
 `,
-		"Name:", color.CyanString(m.params.Name),
-		"Region:", color.CyanString(m.params.Region),
-		"Kubernetes Version:", color.CyanString(m.params.KubernetesVersion),
-		"CNI:", color.CyanString(m.params.CNI),
+		bullet, "Name:", color.CyanString(m.params.Name),
+		bullet, "Region:", color.CyanString(m.params.Region),
+		bullet, "Kubernetes Version:", color.CyanString(m.params.KubernetesVersion),
+		bullet, "CNI:", color.CyanString(m.params.CNI),
 	)
 
 	if len(m.params.SpotNodePools) > 0 {
 		fmt.Println("\nSpot Node Pools:")
 		for _, pool := range m.params.SpotNodePools {
-			fmt.Printf(`  • %s
+			fmt.Printf(`  %s %s
     %-15s %s
     %-15s %d
     %-15s $%s
 `,
-				color.CyanString(pool.Name),
+				bullet, color.CyanString(pool.Name),
 				"Instance Type:", pool.ServerClass,
 				"Desired Nodes:", pool.Desired,
 				"Bid Price:", pool.BidPrice,
@@ -1168,11 +2243,11 @@ Cloudspace Configuration:
 	if len(m.params.OnDemandNodePools) > 0 {
 		fmt.Println("\nOn-Demand Node Pools:")
 		for _, pool := range m.params.OnDemandNodePools {
-			fmt.Printf(`  • %s
+			fmt.Printf(`  %s %s
     %-15s %s
     %-15s %d
     %-15s %s\n\n`,
-				color.CyanString(pool.Name),
+				bullet, color.CyanString(pool.Name),
 				"Instance Type:", pool.ServerClass,
 				"Desired Nodes:", pool.Desired,
 				"Price:", pool.OnDemandPricePerHour,
@@ -1180,7 +2255,7 @@ Cloudspace Configuration:
 		}
 	}
 
-	ok, err := internal.Confirm("\nCreate cloudspace with the above configuration?", true)
+	ok, err := internal.Confirm(m.ctx, "\nCreate cloudspace with the above configuration?", true)
 	if err != nil {
 		return fmt.Errorf("confirmation failed: %w", err)
 	}
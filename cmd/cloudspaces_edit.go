@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/uuid"
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+	"github.com/rackspace-spot/spotctl/internal"
+	"github.com/rackspace-spot/spotctl/internal/ui"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	cloudspacesEditCmd.Flags().String("name", "", "Cloudspace name (required)")
+	cloudspacesEditCmd.Flags().String("org", "", "Organization ID")
+	cloudspacesEditCmd.MarkFlagRequired("name")
+}
+
+// cloudspacesEditCmd represents the cloudspaces edit command
+var cloudspacesEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit a cloudspace and its node pools",
+	Long: `Open the cloudspace and its node pools in $EDITOR and apply the changes on save.
+
+Only node pool changes are applied: existing pools are updated (desired, bid price, autoscaling,
+custom labels and annotations) and new pools are created. Pools removed from the manifest are not deleted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		ctx := cmd.Context()
+		current, err := exportCloudspaceManifest(ctx, client, org, name)
+		if err != nil {
+			return err
+		}
+
+		original, err := yaml.Marshal(current)
+		if err != nil {
+			return fmt.Errorf("failed to encode cloudspace: %w", err)
+		}
+
+		edited, err := editInEditor(name, original)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(bytes.TrimSpace(original), bytes.TrimSpace(edited)) {
+			fmt.Fprintln(os.Stderr, "Edit cancelled, no changes made.")
+			return nil
+		}
+
+		var desired cloudspaceManifest
+		if err := yaml.Unmarshal(edited, &desired); err != nil {
+			return fmt.Errorf("failed to parse edited cloudspace: %w", err)
+		}
+
+		steps := ui.NewStepTracker(os.Stderr, 0, quiet)
+		notes, err := applyCloudspaceManifest(ctx, client, org, current, &desired, steps)
+		for _, note := range notes {
+			fmt.Fprintln(os.Stderr, note)
+		}
+		if err != nil {
+			return err
+		}
+		if steps.Count() == 0 {
+			fmt.Fprintln(os.Stderr, "No changes to apply.")
+		}
+		return nil
+	},
+}
+
+// exportCloudspaceManifest fetches a cloudspace and its node pools as an editable manifest
+func exportCloudspaceManifest(ctx context.Context, client *internal.Client, org, name string) (*cloudspaceManifest, error) {
+	cs, err := client.GetCloudspace(ctx, org, name)
+	if err != nil {
+		if rxtspot.IsNotFound(err) {
+			return nil, fmt.Errorf("cloudspace '%s' not found", name)
+		}
+		return nil, fmt.Errorf("failed to get cloudspace: %w", err)
+	}
+	spotPools, err := client.ListSpotNodePools(ctx, org, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spot node pools: %w", err)
+	}
+	onDemandPools, err := client.ListOnDemandNodePools(ctx, org, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list on-demand node pools: %w", err)
+	}
+
+	// Only carry the user-editable fields so the manifest stays readable
+	manifest := &cloudspaceManifest{
+		CloudSpace: rxtspot.CloudSpace{
+			Name:                 cs.Name,
+			Org:                  cs.Org,
+			Region:               cs.Region,
+			KubernetesVersion:    cs.KubernetesVersion,
+			CNI:                  cs.CNI,
+			PreemptionWebhookURL: cs.PreemptionWebhookURL,
+		},
+	}
+	for _, p := range spotPools {
+		pool := rxtspot.SpotNodePool{
+			Name:              p.Name,
+			Org:               p.Org,
+			Cloudspace:        p.Cloudspace,
+			ServerClass:       p.ServerClass,
+			BidPrice:          p.BidPrice,
+			Desired:           p.Desired,
+			CustomLabels:      p.CustomLabels,
+			CustomAnnotations: p.CustomAnnotations,
+		}
+		pool.Autoscaling = p.Autoscaling
+		manifest.SpotNodePools = append(manifest.SpotNodePools, pool)
+	}
+	for _, p := range onDemandPools {
+		pool := rxtspot.OnDemandNodePool{
+			Name:              p.Name,
+			Org:               p.Org,
+			Cloudspace:        p.Cloudspace,
+			ServerClass:       p.ServerClass,
+			Desired:           p.Desired,
+			CustomLabels:      p.CustomLabels,
+			CustomAnnotations: p.CustomAnnotations,
+		}
+		pool.Autoscaling = p.Autoscaling
+		manifest.OnDemandNodePools = append(manifest.OnDemandNodePools, pool)
+	}
+	return manifest, nil
+}
+
+// editInEditor writes content to a temp file, opens it in the user's editor and returns the saved content
+func editInEditor(name string, content []byte) ([]byte, error) {
+	f, err := os.CreateTemp("", "spotctl-edit-"+name+"-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// Editors are often configured with arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	editorCmd := exec.Command(parts[0], append(parts[1:], f.Name())...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %q exited with an error, no changes applied: %w", editor, err)
+	}
+
+	edited, err := os.ReadFile(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return edited, nil
+}
+
+// applyCloudspaceManifest reconciles the node pools of a cloudspace towards the desired manifest,
+// reporting each create or update as a step. It returns notes about pools that were removed
+// from the manifest, which are never deleted.
+func applyCloudspaceManifest(ctx context.Context, client *internal.Client, org string, current, desired *cloudspaceManifest, steps *ui.StepTracker) ([]string, error) {
+	var notes []string
+	defer client.InvalidateCloudspace(org, current.CloudSpace.Name)
+
+	cur, want := current.CloudSpace, desired.CloudSpace
+	if cur.Name != want.Name || cur.Region != want.Region || cur.KubernetesVersion != want.KubernetesVersion ||
+		cur.CNI != want.CNI || cur.PreemptionWebhookURL != want.PreemptionWebhookURL {
+		return nil, fmt.Errorf("cloudspace fields cannot be changed in place; only node pools can be edited")
+	}
+
+	existingSpot := make(map[string]rxtspot.SpotNodePool)
+	for _, p := range current.SpotNodePools {
+		existingSpot[p.Name] = p
+	}
+	seenSpot := make(map[string]bool)
+	for _, p := range desired.SpotNodePools {
+		if p.Name != "" {
+			seenSpot[p.Name] = true
+		}
+		old, exists := existingSpot[p.Name]
+		if !exists {
+			bidPrice, err := validateBidPrice(p.BidPrice)
+			if err != nil {
+				return notes, fmt.Errorf("invalid bid price for new spot node pool: %w", err)
+			}
+			pool := rxtspot.SpotNodePool{
+				Name:              newPoolName(p.Name),
+				Org:               org,
+				Cloudspace:        cur.Name,
+				ServerClass:       p.ServerClass,
+				BidPrice:          bidPrice,
+				Desired:           p.Desired,
+				CustomLabels:      p.CustomLabels,
+				CustomAnnotations: p.CustomAnnotations,
+			}
+			pool.Autoscaling = p.Autoscaling
+			steps.Start("Creating spot node pool %s", pool.Name)
+			if err := client.GetAPI().CreateSpotNodePool(ctx, org, pool); err != nil {
+				return notes, steps.Fail(fmt.Errorf("failed to create spot node pool %s: %w", pool.Name, err))
+			}
+			steps.Done()
+			continue
+		}
+		if old.ServerClass != p.ServerClass {
+			return notes, fmt.Errorf("server class of spot node pool %s cannot be changed", p.Name)
+		}
+		spec := nodePoolSpecPatch(old.Desired, p.Desired, old.CustomLabels, p.CustomLabels, old.CustomAnnotations, p.CustomAnnotations)
+		if bidValue(old.BidPrice) != bidValue(p.BidPrice) {
+			bidPrice, err := validateBidPrice(strings.TrimPrefix(p.BidPrice, "$"))
+			if err != nil {
+				return notes, fmt.Errorf("invalid bid price for spot node pool %s: %w", p.Name, err)
+			}
+			spec["bidPrice"] = bidPrice
+		}
+		if old.Autoscaling != p.Autoscaling {
+			spec["autoscaling"] = p.Autoscaling
+		}
+		if len(spec) == 0 {
+			continue
+		}
+		steps.Start("Updating spot node pool %s", p.Name)
+		if err := client.PatchNodePoolSpec(ctx, org, p.Name, true, spec); err != nil {
+			return notes, steps.Fail(fmt.Errorf("failed to update spot node pool %s: %w", p.Name, err))
+		}
+		steps.Done()
+	}
+	for name := range existingSpot {
+		if !seenSpot[name] {
+			notes = append(notes, fmt.Sprintf("spot nodepool - %s was removed from the manifest but not deleted (use 'spotctl nodepools spot delete')", name))
+		}
+	}
+
+	existingOnDemand := make(map[string]rxtspot.OnDemandNodePool)
+	for _, p := range current.OnDemandNodePools {
+		existingOnDemand[p.Name] = p
+	}
+	seenOnDemand := make(map[string]bool)
+	for _, p := range desired.OnDemandNodePools {
+		if p.Name != "" {
+			seenOnDemand[p.Name] = true
+		}
+		old, exists := existingOnDemand[p.Name]
+		if !exists {
+			pool := rxtspot.OnDemandNodePool{
+				Name:              newPoolName(p.Name),
+				Org:               org,
+				Cloudspace:        cur.Name,
+				ServerClass:       p.ServerClass,
+				Desired:           p.Desired,
+				CustomLabels:      p.CustomLabels,
+				CustomAnnotations: p.CustomAnnotations,
+			}
+			pool.Autoscaling = p.Autoscaling
+			steps.Start("Creating on-demand node pool %s", pool.Name)
+			if err := client.GetAPI().CreateOnDemandNodePool(ctx, org, pool); err != nil {
+				return notes, steps.Fail(fmt.Errorf("failed to create on-demand node pool %s: %w", pool.Name, err))
+			}
+			steps.Done()
+			continue
+		}
+		if old.ServerClass != p.ServerClass {
+			return notes, fmt.Errorf("server class of on-demand node pool %s cannot be changed", p.Name)
+		}
+		spec := nodePoolSpecPatch(old.Desired, p.Desired, old.CustomLabels, p.CustomLabels, old.CustomAnnotations, p.CustomAnnotations)
+		if old.Autoscaling != p.Autoscaling {
+			spec["autoscaling"] = p.Autoscaling
+		}
+		if len(spec) == 0 {
+			continue
+		}
+		steps.Start("Updating on-demand node pool %s", p.Name)
+		if err := client.PatchNodePoolSpec(ctx, org, p.Name, false, spec); err != nil {
+			return notes, steps.Fail(fmt.Errorf("failed to update on-demand node pool %s: %w", p.Name, err))
+		}
+		steps.Done()
+	}
+	for name := range existingOnDemand {
+		if !seenOnDemand[name] {
+			notes = append(notes, fmt.Sprintf("on-demand nodepool - %s was removed from the manifest but not deleted (use 'spotctl nodepools ondemand delete')", name))
+		}
+	}
+
+	return notes, nil
+}
+
+// nodePoolSpecPatch returns a merge patch of the desired count and custom labels and annotations
+// that a spot and an on-demand pool have in common, holding only the fields that changed
+func nodePoolSpecPatch(oldDesired, desired int, oldLabels, labels, oldAnnotations, annotations map[string]string) map[string]interface{} {
+	spec := map[string]interface{}{}
+	if oldDesired != desired {
+		spec["desired"] = desired
+	}
+	if patch := stringMapPatch(oldLabels, labels); len(patch) > 0 {
+		spec["customLabels"] = patch
+	}
+	if patch := stringMapPatch(oldAnnotations, annotations); len(patch) > 0 {
+		spec["customAnnotations"] = patch
+	}
+	return spec
+}
+
+// stringMapPatch returns a merge patch turning old into updated: added and changed keys with
+// their new value and removed keys set to nil
+func stringMapPatch(old, updated map[string]string) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k, v := range updated {
+		if ov, ok := old[k]; !ok || ov != v {
+			patch[k] = v
+		}
+	}
+	for k := range old {
+		if _, ok := updated[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	return patch
+}
+
+// newPoolName returns the given pool name, or a fresh UUID when none was provided
+func newPoolName(name string) string {
+	if name == "" {
+		return uuid.NewString()
+	}
+	return name
+}
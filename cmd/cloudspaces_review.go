@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rackspace-spot/spotctl/internal"
+	"github.com/rackspace-spot/spotctl/internal/ui"
+)
+
+// Choices of the node pool review screen of the create wizard
+const (
+	reviewContinue = "Continue to summary"
+	reviewAddPool  = "Add a node pool"
+	reviewDesired  = "Edit desired nodes"
+	reviewBid      = "Edit bid price"
+	reviewDelete   = "Delete this pool"
+	reviewBack     = "Back"
+)
+
+// stepReviewNodePools lists the collected node pools and lets the user delete a pool or change
+// its desired nodes or bid before the final confirmation, instead of restarting the wizard
+func (m *interactiveModel) stepReviewNodePools() error {
+	for {
+		fmt.Printf("\n%s Review node pools:\n", color.GreenString("?"))
+		labels := m.nodePoolLabels()
+		choice, err := m.selectChoice(append(slices.Clone(labels), reviewAddPool, reviewContinue), reviewContinue)
+		if err != nil || m.cancelled {
+			return err
+		}
+		switch choice {
+		case reviewContinue:
+			if len(labels) == 0 {
+				fmt.Println("At least one node pool is required.")
+				continue
+			}
+			return nil
+		case reviewAddPool:
+			if err := m.stepAddNodePools(); err != nil || m.cancelled {
+				return err
+			}
+			continue
+		}
+		if i := slices.Index(labels, choice); i >= 0 {
+			if err := m.reviewNodePool(i); err != nil || m.cancelled {
+				return err
+			}
+		}
+	}
+}
+
+// nodePoolLabels describes every collected pool, spot pools first, in the order
+// reviewNodePool indexes them
+func (m *interactiveModel) nodePoolLabels() []string {
+	var labels []string
+	for _, p := range m.params.SpotNodePools {
+		labels = append(labels, fmt.Sprintf("spot %s, %d node(s), bid $%s (%s)", p.ServerClass, p.Desired, p.BidPrice, p.Name))
+	}
+	for _, p := range m.params.OnDemandNodePools {
+		labels = append(labels, fmt.Sprintf("on-demand %s, %d node(s) (%s)", p.ServerClass, p.Desired, p.Name))
+	}
+	return labels
+}
+
+// reviewNodePool offers the edits of the i-th pool of nodePoolLabels
+func (m *interactiveModel) reviewNodePool(i int) error {
+	spot := i < len(m.params.SpotNodePools)
+	var (
+		name    string
+		desired *int
+	)
+	if spot {
+		name, desired = m.params.SpotNodePools[i].Name, &m.params.SpotNodePools[i].Desired
+	} else {
+		i -= len(m.params.SpotNodePools)
+		name, desired = m.params.OnDemandNodePools[i].Name, &m.params.OnDemandNodePools[i].Desired
+	}
+	actions := []string{reviewDesired}
+	if spot {
+		actions = append(actions, reviewBid)
+	}
+	actions = append(actions, reviewDelete, reviewBack)
+	action, err := m.selectChoice(actions, reviewBack)
+	if err != nil || m.cancelled {
+		return err
+	}
+
+	switch action {
+	case reviewDesired:
+		value, err := m.promptText("Enter desired nodes", strconv.Itoa(*desired), requirePositiveInt("Desired nodes"))
+		if err != nil {
+			return m.handlePromptError("desired nodes input failed", err)
+		}
+		*desired, _ = parseDesired(value, false)
+	case reviewBid:
+		pool := &m.params.SpotNodePools[i]
+		// Prices are only advisory here; without them the bid is checked for format only
+		var minBid, onDemand string
+		if prices, err := m.client.RegionPricing(m.ctx, m.params.Region); err == nil {
+			if p, ok := prices[pool.ServerClass]; ok {
+				if v := p.EffectiveMinBid(); v > 0 {
+					minBid = strconv.FormatFloat(v, 'f', -1, 64)
+				}
+				if p.OnDemand > 0 {
+					onDemand = strconv.FormatFloat(p.OnDemand, 'f', -1, 64)
+				}
+			}
+		}
+		msg := "Enter your maximum bid price"
+		if minBid != "" {
+			msg = fmt.Sprintf("Enter your maximum bid price (minimum: $%s)", minBid)
+		}
+		for {
+			value, err := m.promptText(msg, pool.BidPrice, requireNonEmpty("Bid price"))
+			if err != nil {
+				return m.handlePromptError("bid price input failed", err)
+			}
+			bid, ok, err := m.checkBid(value, minBid, onDemand)
+			if err != nil {
+				return err
+			}
+			if ok {
+				pool.BidPrice = bid
+				break
+			}
+		}
+	case reviewDelete:
+		ok, err := internal.Confirm(m.ctx, fmt.Sprintf("Delete node pool %s?", name), false)
+		if err != nil {
+			return m.handlePromptError("confirmation failed", err)
+		}
+		if !ok {
+			return nil
+		}
+		if spot {
+			m.params.SpotNodePools = slices.Delete(m.params.SpotNodePools, i, i+1)
+			if i < len(m.params.SpotPriorities) {
+				m.params.SpotPriorities = slices.Delete(m.params.SpotPriorities, i, i+1)
+			}
+		} else {
+			m.params.OnDemandNodePools = slices.Delete(m.params.OnDemandNodePools, i, i+1)
+			if i < len(m.params.OnDemandPriorities) {
+				m.params.OnDemandPriorities = slices.Delete(m.params.OnDemandPriorities, i, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// selectChoice shows a selection list and returns the chosen option, or "" with m.cancelled
+// set when the user cancelled it
+func (m *interactiveModel) selectChoice(options []string, defaultChoice string) (string, error) {
+	m2, err := internal.RunProgram(m.ctx, ui.NewSelectModel(options).WithDefault(defaultChoice))
+	if err != nil {
+		return "", m.handlePromptError("selection failed", err)
+	}
+	sm, ok := m2.(ui.SelectModel)
+	if !ok || sm.Cancelled() || sm.Selected() == "" {
+		m.cancelled = true
+		return "", nil
+	}
+	return sm.Selected(), nil
+}
+
+// requirePositiveInt returns a validator accepting whole numbers of at least 1
+func requirePositiveInt(what string) func(string) error {
+	return func(value string) error {
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || n < 1 {
+			return fmt.Errorf("%s must be a whole number of at least 1", what)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rackspace-spot/spotctl/internal"
+)
+
+func TestGenerateCloudspaceName(t *testing.T) {
+	// The fake API answers every cloudspace GET with a 404, so the first candidate is free
+	api := newFakeAPI(t)
+	client, err := internal.NewClientWithTokens("test-refresh", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := generateCloudspaceName(context.Background(), client, "hooli", "dev-")
+	if err != nil {
+		t.Fatalf("generateCloudspaceName failed: %v", err)
+	}
+	if !strings.HasPrefix(name, "dev-") || len(name) != len("dev-")+5 {
+		t.Errorf("name = %q, want dev- followed by a 5 character suffix", name)
+	}
+	path := "/apis/ngpc.rxt.io/v1/namespaces/" + fakeOrgNamespace + "/cloudspaces/" + name
+	if got := len(api.received("GET", path)); got != 1 {
+		t.Errorf("got %d GET requests for %s, want 1", got, path)
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the spotctl config file",
+	Long:  `Manage the spotctl config file.`,
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade a legacy config file",
+	Long: `Move a legacy ~/.spot_config to the XDG config location and rewrite it in the current format.
+The original file is kept with a .bak suffix.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := config.MigrateConfig()
+		if err != nil {
+			return fmt.Errorf("failed to migrate config: %w", err)
+		}
+		if result == nil {
+			fmt.Fprintln(os.Stderr, "Config file is already up to date.")
+			return nil
+		}
+		if cmd.Flags().Changed("output") {
+			return internal.OutputData(result, outputFormat)
+		}
+		fmt.Fprintf(os.Stderr, "Migrated %s to %s (backup: %s)\n", result.From, result.To, result.Backup)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configMigrateCmd)
+}
@@ -7,7 +7,9 @@ import (
 	"os"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/rackspace-spot/spotctl/internal"
+	"github.com/rackspace-spot/spotctl/internal/ui"
 	config "github.com/rackspace-spot/spotctl/pkg"
 	"github.com/spf13/cobra"
 )
@@ -15,25 +17,30 @@ import (
 var configureCmd = &cobra.Command{
 	Use:   "configure",
 	Short: "Set up Spot CLI defaults",
-	Long:  `configure default orgID, token, and region for the Spot CLI.`,
+	Long: `configure default orgID, token, and region for the Spot CLI.
+
+The refresh token is saved in the config file unless --credential-store keyring keeps it in
+the OS keyring (macOS Keychain, Windows Credential Manager or the Linux Secret Service), in
+which case the file holds only the org, region and preferences. Without the flag, configure
+asks which store to use.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		reader := bufio.NewReader(os.Stdin)
 
-		fmt.Print("Organization ID: ")
+		fmt.Fprint(os.Stderr, "Organization ID: ")
 		orgID, err := reader.ReadString('\n')
 		if err != nil {
 			return fmt.Errorf("failed to read organization ID: %w", err)
 		}
 		orgID = strings.TrimSpace(orgID)
 
-		fmt.Print("Refresh Token: ")
+		fmt.Fprint(os.Stderr, "Refresh Token: ")
 		refreshToken, err := reader.ReadString('\n')
 		if err != nil {
 			return fmt.Errorf("failed to read refresh token: %w", err)
 		}
 		refreshToken = strings.TrimSpace(refreshToken)
 
-		fmt.Print("Preferred Region: ")
+		fmt.Fprint(os.Stderr, "Preferred Region: ")
 		region, err := reader.ReadString('\n')
 		if err != nil {
 			return fmt.Errorf("failed to read preferred region: %w", err)
@@ -44,7 +51,18 @@ var configureCmd = &cobra.Command{
 			return fmt.Errorf("region is required")
 		}
 		if !isValidRegion(region) {
-			return fmt.Errorf("region %s is not valid. Available regions: %s, %s, %s, %s, %s, %s, %s, %s", region, US_CENTRAL_ORD_1, HKG_HKG_1, AUS_SYD_1, UK_LON_1, US_EAST_IAD_1, US_CENTRAL_DFW_1, US_CENTRAL_DFW_2, US_WEST_SJC_1)
+			return invalidRegionError(region)
+		}
+
+		// In test mode only report whether the credentials work; never touch the saved config
+		if test, _ := cmd.Flags().GetBool("test"); test {
+			return testCredentials(cmd.Context(), refreshToken, orgID, region)
+		}
+
+		existing, _ := config.LoadConfig()
+		store, err := chooseCredentialStore(cmd, reader, existing)
+		if err != nil {
+			return err
 		}
 
 		client, err := internal.NewClientWithTokens(refreshToken, "")
@@ -55,22 +73,148 @@ var configureCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
+		// Keep an existing certificate pin unless a new one is given, and any preferred output format
+		pin := pinCertSHA256
+		var preferredOutput string
+		if existing != nil {
+			if pin == "" {
+				pin = existing.PinCertSHA256
+			}
+			preferredOutput = existing.OutputFormat
+		}
 		cfg := &config.SpotConfig{
-			Org:          orgID,
-			RefreshToken: refreshToken,
-			AccessToken:  access_token,
-			Region:       region,
+			Org:             orgID,
+			RefreshToken:    refreshToken,
+			AccessToken:     access_token,
+			Region:          region,
+			PinCertSHA256:   pin,
+			OutputFormat:    preferredOutput,
+			CredentialStore: store,
 		}
 
 		if err := config.SaveConfig(cfg); err != nil {
 			return fmt.Errorf("failed to save configuration: %w", err)
 		}
+		// A token left in the keyring after switching back to the file would never be used
+		if existing != nil && existing.UsesKeyring() && !cfg.UsesKeyring() {
+			if err := config.DeleteKeyringToken(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
 
-		fmt.Println("Configuration saved to ~/.spot_config")
+		path, err := config.GetConfigPath()
+		if err != nil {
+			return err
+		}
+		if cfg.UsesKeyring() {
+			fmt.Fprintf(os.Stderr, "Configuration saved to %s, refresh token stored in the OS keyring\n", path)
+		} else {
+			fmt.Fprintf(os.Stderr, "Configuration saved to %s\n", path)
+		}
+		// With an explicit -o, print the saved configuration so setup scripts can verify it
+		if cmd.Flags().Changed("output") {
+			summary := newConfigSummary(cfg, path)
+			summary.Authenticated = true
+			return internal.OutputData(summary, outputFormat)
+		}
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(configureCmd)
+	configureCmd.Flags().Bool("test", false, "Validate the credentials, organization and region without saving the configuration")
+	configureCmd.Flags().String("credential-store", "", "Where to keep the refresh token: file (the config file) or keyring (the OS keyring)")
+}
+
+// chooseCredentialStore returns the credential store to save with: --credential-store when
+// given, otherwise the answer to a prompt defaulting to the store of the existing config.
+// With --no-input the existing store is kept, and new configs use the file.
+func chooseCredentialStore(cmd *cobra.Command, reader *bufio.Reader, existing *config.SpotConfig) (string, error) {
+	if cmd.Flags().Changed("credential-store") {
+		store, _ := cmd.Flags().GetString("credential-store")
+		store = strings.ToLower(strings.TrimSpace(store))
+		if err := config.ValidateCredentialStore(store); err != nil {
+			return "", err
+		}
+		return store, nil
+	}
+	useKeyring := existing != nil && existing.UsesKeyring()
+	if noInput {
+		if useKeyring {
+			return config.CredentialStoreKeyring, nil
+		}
+		return config.CredentialStoreFile, nil
+	}
+
+	choices := "(y/N)"
+	if useKeyring {
+		choices = "(Y/n)"
+	}
+	fmt.Fprintf(os.Stderr, "Store the refresh token in the OS keyring instead of the config file? %s: ", choices)
+	answer, err := reader.ReadString('\n')
+	if err != nil && answer == "" {
+		return "", fmt.Errorf("failed to read credential store choice: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		useKeyring = true
+	case "n", "no":
+		useKeyring = false
+	}
+	if useKeyring {
+		return config.CredentialStoreKeyring, nil
+	}
+	return config.CredentialStoreFile, nil
+}
+
+// testCredentials authenticates and performs lightweight reads to confirm the org and region,
+// printing a pass/fail line for each check
+func testCredentials(ctx context.Context, refreshToken, orgID, region string) error {
+	pass := func(msg string, args ...interface{}) {
+		fmt.Printf("%s %s\n", color.GreenString(ui.CheckMark()), fmt.Sprintf(msg, args...))
+	}
+	failed := 0
+	fail := func(msg string, args ...interface{}) {
+		failed++
+		fmt.Printf("%s %s\n", color.RedString(ui.CrossMark()), fmt.Sprintf(msg, args...))
+	}
+
+	client, err := internal.NewClientWithTokens(refreshToken, "")
+	if err != nil {
+		fail("Authentication: %v", err)
+		return fmt.Errorf("credential test failed")
+	}
+	pass("Authentication")
+
+	if orgID == "" {
+		fail("Organization: not specified")
+	} else if orgs, err := client.GetAPI().ListOrganizations(ctx); err != nil {
+		fail("Organization '%s': failed to list organizations: %v", orgID, err)
+	} else {
+		found := false
+		for _, organization := range orgs {
+			if organization.Name == orgID {
+				found = true
+				break
+			}
+		}
+		if found {
+			pass("Organization '%s' is accessible", orgID)
+		} else {
+			fail("Organization '%s' is not accessible with this token", orgID)
+		}
+	}
+
+	if _, err := client.GetAPI().GetRegion(ctx, region); err != nil {
+		fail("Region '%s': %v", region, err)
+	} else {
+		pass("Region '%s' is available", region)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("credential test failed: %d check(s) did not pass", failed)
+	}
+	fmt.Println("All checks passed. Configuration was not saved.")
+	return nil
 }
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+	"github.com/spf13/cobra"
+	"k8s.io/klog"
+)
+
+const (
+	// defaultConflictRetries is the number of retries of --retry-on-conflict given without a value
+	defaultConflictRetries = 3
+	// conflictBackoff is the delay before the first retry after a conflict; it doubles on every retry
+	conflictBackoff = 500 * time.Millisecond
+)
+
+// addRetryOnConflictFlag adds --retry-on-conflict to cmd, describing what a retry does
+func addRetryOnConflictFlag(cmd *cobra.Command, retry string) {
+	cmd.Flags().Int("retry-on-conflict", 0, fmt.Sprintf("Retry up to this many times (%d when given without a value) when the API reports a conflict, %s", defaultConflictRetries, retry))
+	cmd.Flags().Lookup("retry-on-conflict").NoOptDefVal = fmt.Sprint(defaultConflictRetries)
+}
+
+// conflictRetries returns the value of --retry-on-conflict
+func conflictRetries(cmd *cobra.Command) (int, error) {
+	retries, _ := cmd.Flags().GetInt("retry-on-conflict")
+	if retries < 0 {
+		return 0, fmt.Errorf("--retry-on-conflict must not be negative")
+	}
+	return retries, nil
+}
+
+// retryOnConflict calls fn until it succeeds, fails with an error other than a conflict, or
+// has been retried retries times, backing off exponentially between attempts. fn receives the
+// attempt number, starting at 0, so it can re-read the object it changes before retrying.
+func retryOnConflict(ctx context.Context, what string, retries int, fn func(attempt int) error) error {
+	backoff := conflictBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn(attempt)
+		if err == nil || !rxtspot.IsConflict(err) || attempt >= retries {
+			return err
+		}
+		klog.V(1).Infof("conflict on %s: %v", what, err)
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Conflict on %s, retrying in %s (%d/%d)\n", what, backoff, attempt+1, retries)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
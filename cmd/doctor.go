@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rackspace-spot/spotctl/internal"
+	"github.com/rackspace-spot/spotctl/internal/ui"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+// Statuses of a doctor check
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// doctorCheck is the outcome of one doctor check
+type doctorCheck struct {
+	Name    string `json:"name" yaml:"name"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message" yaml:"message"`
+	Hint    string `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+// doctorReport is the structured output of doctor
+type doctorReport struct {
+	Healthy  bool          `json:"healthy" yaml:"healthy"`
+	Critical int           `json:"critical" yaml:"critical"`
+	Warnings int           `json:"warnings" yaml:"warnings"`
+	Checks   []doctorCheck `json:"checks" yaml:"checks"`
+}
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose configuration, authentication and connectivity problems",
+	Long: `Run a series of checks against the local configuration and the Spot API and print
+a checklist with remediation hints. With an explicit --output the checklist is printed as a
+report instead. Exits with code 1 if any critical check fails; warnings do not change the
+exit code.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		structured := structuredOutput(cmd)
+		report := doctorReport{Checks: []doctorCheck{}}
+		record := func(c doctorCheck) {
+			report.Checks = append(report.Checks, c)
+			if structured {
+				return
+			}
+			switch c.Status {
+			case checkPass:
+				fmt.Printf("%s %s\n", color.GreenString(ui.CheckMark()), c.Message)
+			case checkWarn:
+				fmt.Printf("%s %s\n", color.YellowString("!"), c.Message)
+			case checkFail:
+				fmt.Printf("%s %s\n", color.RedString(ui.CrossMark()), c.Message)
+			default:
+				fmt.Printf("%s %s (skipped)\n", color.HiBlackString("-"), c.Message)
+			}
+			if c.Hint != "" {
+				fmt.Printf("    %s\n", c.Hint)
+			}
+		}
+		pass := func(name, msg string, args ...interface{}) {
+			record(doctorCheck{Name: name, Status: checkPass, Message: fmt.Sprintf(msg, args...)})
+		}
+		warn := func(name, hint, msg string, args ...interface{}) {
+			report.Warnings++
+			record(doctorCheck{Name: name, Status: checkWarn, Message: fmt.Sprintf(msg, args...), Hint: hint})
+		}
+		fail := func(name, hint, msg string, args ...interface{}) {
+			report.Critical++
+			record(doctorCheck{Name: name, Status: checkFail, Message: fmt.Sprintf(msg, args...), Hint: hint})
+		}
+		skip := func(name, msg string) {
+			record(doctorCheck{Name: name, Status: checkSkip, Message: msg})
+		}
+
+		// Config file presence and permissions
+		var cfg *config.SpotConfig
+		path, err := config.GetConfigPath()
+		if err != nil {
+			fail("config-file", "Make sure $HOME is set.", "Config file: cannot determine location: %v", err)
+		} else if info, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			// Without a config file the CLI still works from SPOT_REFRESH_TOKEN
+			if os.Getenv("SPOT_REFRESH_TOKEN") != "" {
+				warn("config-file", "Run 'spotctl configure' to create it.", "Config file %s not found, using SPOT_REFRESH_TOKEN", path)
+			} else {
+				warn("config-file", "Run 'spotctl configure' to create it, or set SPOT_REFRESH_TOKEN.", "Config file %s not found", path)
+			}
+			cfg = &config.SpotConfig{}
+			if err := config.ResolveTokens(cfg); err != nil {
+				fail("credential-store", "Unset SPOT_REFRESH_TOKEN or run 'spotctl configure'.", "Refresh token cannot be read: %v", err)
+				cfg.RefreshToken = ""
+			}
+		} else if err != nil {
+			fail("config-file", "Check the permissions of the config directory.", "Config file %s cannot be read: %v", path, err)
+		} else {
+			pass("config-file", "Config file %s exists", path)
+			if perm := info.Mode().Perm(); perm != 0600 {
+				warn("config-permissions", fmt.Sprintf("Run 'chmod 600 %s' so other users cannot read your token.", path), "Config file permissions are %#o, expected 0600", perm)
+			} else {
+				pass("config-permissions", "Config file permissions are 0600")
+			}
+			if cfg, err = config.LoadConfig(); err != nil {
+				fail("config-parse", "Fix the YAML syntax or re-run 'spotctl configure'.", "Config file cannot be parsed: %v", err)
+			} else if err := config.ResolveTokens(cfg); err != nil {
+				fail("credential-store", "Unlock the OS keyring, or run 'spotctl configure --credential-store file'.", "Refresh token cannot be read: %v", err)
+				cfg.RefreshToken = ""
+			}
+		}
+
+		// Endpoint reachability
+		baseURL := internal.DefaultConfig().BaseURL
+		httpClient := &http.Client{Timeout: 10 * time.Second}
+		if resp, err := httpClient.Get(baseURL); err != nil {
+			fail("api-endpoint", "Check your network, proxy settings and --base-url or SPOT_BASE_URL.", "API endpoint %s is not reachable: %v", baseURL, err)
+		} else {
+			resp.Body.Close()
+			pass("api-endpoint", "API endpoint %s is reachable", baseURL)
+			if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+				if skew := time.Since(date); skew.Abs() > internal.MaxClockSkew {
+					warn("clock-skew", "Check the system clock and NTP synchronization; tokens are rejected when the clock is off.", "Local clock differs from the API server by %s", skew.Abs().Round(time.Second))
+				} else {
+					pass("clock-skew", "Local clock is in sync with the API server")
+				}
+			}
+		}
+
+		// Credentials, region and organizations
+		if cfg == nil {
+			skip("refresh-token", "Refresh token")
+			skip("authentication", "Authentication")
+			skip("region", "Region")
+			skip("organizations", "Organizations")
+		} else {
+			var client *internal.Client
+			if strings.TrimSpace(cfg.RefreshToken) == "" {
+				fail("refresh-token", "Run 'spotctl configure' with a refresh token from the Spot console.", "Refresh token is not set")
+				skip("authentication", "Authentication")
+			} else {
+				pass("refresh-token", "Refresh token is set")
+				client, err = internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+				if err != nil {
+					fail("authentication", "Generate a new token in the Spot console and run 'spotctl configure'.", "Authentication failed: %v", err)
+				} else {
+					pass("authentication", "Authentication succeeded")
+				}
+			}
+
+			if cfg.Region == "" {
+				warn("region", "Run 'spotctl configure' to set a default region.", "No default region configured")
+			} else if !isValidRegion(cfg.Region) {
+				hint := fmt.Sprintf("Set region to one of: %s.", strings.Join(validRegions, ", "))
+				if suggestion, ok := suggestRegion(cfg.Region); ok {
+					hint = fmt.Sprintf("Did you mean '%s'? %s", suggestion, hint)
+				}
+				fail("region", hint, "Region '%s' is not valid", cfg.Region)
+			} else {
+				pass("region", "Region '%s' is valid", cfg.Region)
+			}
+
+			if client == nil {
+				skip("organizations", "Organizations")
+			} else if orgs, err := client.GetAPI().ListOrganizations(ctx); err != nil {
+				fail("organizations", "Re-run with --http-debug for details.", "Failed to list organizations: %v", err)
+			} else if len(orgs) == 0 {
+				fail("organizations", "Ask an organization admin to invite you.", "No organizations are accessible with this token")
+			} else {
+				pass("organizations", "%d organization(s) accessible", len(orgs))
+			}
+		}
+
+		report.Healthy = report.Critical == 0
+		if structured {
+			if err := internal.OutputData(report, outputFormat); err != nil {
+				return err
+			}
+		}
+		if !report.Healthy {
+			return fmt.Errorf("doctor found %d critical problem(s)", report.Critical)
+		}
+		if !structured {
+			fmt.Println("No problems found.")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
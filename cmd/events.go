@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+// cloudspaceEvent is a change observed on a cloudspace, one of its node pools or servers
+type cloudspaceEvent struct {
+	Time    time.Time `json:"time" yaml:"time"`
+	Type    string    `json:"type" yaml:"type"`
+	Object  string    `json:"object" yaml:"object"`
+	Message string    `json:"message" yaml:"message"`
+}
+
+// eventState is the observable state of one object of a cloudspace
+type eventState struct {
+	summary string
+	warning bool
+	created time.Time
+}
+
+// cloudspaceEventStates flattens a cloudspace into the states that events are derived from,
+// keyed by object ("cloudspace/NAME", "spotnodepool/NAME", "ondemandnodepool/NAME", "server/NAME")
+func cloudspaceEventStates(cs *rxtspot.CloudSpace) map[string]eventState {
+	states := map[string]eventState{}
+	summary := "status " + valueOrDash(cs.Status)
+	if cs.Message != "" {
+		summary += ": " + cs.Message
+	}
+	states["cloudspace/"+cs.Name] = eventState{summary: summary, warning: isFailureStatus(cs.Status), created: cs.CreationTimestamp}
+
+	for _, p := range cs.SpotNodepools {
+		if p == nil {
+			continue
+		}
+		states["spotnodepool/"+p.Name] = eventState{
+			summary: fmt.Sprintf("status %s, %d/%d nodes won at bid %s", valueOrDash(p.Status), p.WonCount, p.Desired, p.BidPrice),
+			warning: isFailureStatus(p.Status) || p.WonCount < p.Desired,
+			created: p.CreationTimestamp,
+		}
+	}
+	for _, p := range cs.OnDemandNodePools {
+		if p == nil {
+			continue
+		}
+		states["ondemandnodepool/"+p.Name] = eventState{
+			summary: fmt.Sprintf("status %s, %d/%d nodes", valueOrDash(p.Status), p.WonCount, p.Desired),
+			warning: isFailureStatus(p.Status),
+			created: p.CreationTimestamp,
+		}
+	}
+	for name, s := range cs.AssignedServers {
+		states["server/"+name] = eventState{
+			summary: fmt.Sprintf("%s %s (%s) %s", s.ClusterRole, s.ServerClassName, valueOrDash(s.IP), valueOrDash(s.State)),
+			warning: isFailureStatus(s.State),
+		}
+	}
+	return states
+}
+
+// isFailureStatus reports whether a status or state names a problem
+func isFailureStatus(status string) bool {
+	s := strings.ToLower(status)
+	for _, bad := range []string{"fail", "error", "preempt", "lost", "degraded"} {
+		if strings.Contains(s, bad) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueOrDash returns s, or "-" when it is empty
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// diffEventStates returns the events turning prev into cur, observed at now. With a nil prev
+// every object is reported with its current state.
+func diffEventStates(prev, cur map[string]eventState, now time.Time) []cloudspaceEvent {
+	var events []cloudspaceEvent
+	for obj, s := range cur {
+		eventType := "Normal"
+		if s.warning {
+			eventType = "Warning"
+		}
+		old, known := prev[obj]
+		switch {
+		case prev == nil:
+			at := now
+			if !s.created.IsZero() {
+				at = s.created
+			}
+			events = append(events, cloudspaceEvent{Time: at, Type: eventType, Object: obj, Message: s.summary})
+		case !known:
+			events = append(events, cloudspaceEvent{Time: now, Type: eventType, Object: obj, Message: "added: " + s.summary})
+		case old.summary != s.summary:
+			events = append(events, cloudspaceEvent{Time: now, Type: eventType, Object: obj, Message: s.summary + " (was " + old.summary + ")"})
+		}
+	}
+	for obj, s := range prev {
+		if _, ok := cur[obj]; ok {
+			continue
+		}
+		// A server disappearing from a running cloudspace is usually a preemption
+		eventType := "Normal"
+		if strings.HasPrefix(obj, "server/") {
+			eventType = "Warning"
+		}
+		events = append(events, cloudspaceEvent{Time: now, Type: eventType, Object: obj, Message: "removed (was " + s.summary + ")"})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.Before(events[j].Time)
+		}
+		return events[i].Object < events[j].Object
+	})
+	return events
+}
+
+// printEvent writes one followed event: a JSON object per line with -o json, otherwise a
+// "time type object message" line
+func printEvent(e cloudspaceEvent) error {
+	if outputFormat == "json" {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	fmt.Printf("%s  %-7s  %s  %s\n", e.Time.Format(time.RFC3339), e.Type, e.Object, e.Message)
+	return nil
+}
+
+// cloudspacesEventsCmd represents the cloudspaces events command
+var cloudspacesEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show provisioning and preemption events of a cloudspace",
+	Long: `Show the state of a cloudspace, its node pools and servers as events. With --follow the
+cloudspace is polled and an event is printed for every change, such as a status transition,
+a node pool winning or losing nodes, or a server being added or preempted.
+
+The API does not keep an event history, so events are derived from the changes observed while
+following; without --follow the current state of each object is listed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		cs, err := client.GetAPI().GetCloudspace(ctx, org, name)
+		if err != nil {
+			return fmt.Errorf("failed to get cloudspace %s: %w", name, err)
+		}
+		states := cloudspaceEventStates(cs)
+		events := diffEventStates(nil, states, time.Now())
+		if follow, _ := cmd.Flags().GetBool("follow"); !follow {
+			return internal.OutputData(events, outputFormat)
+		}
+
+		for _, e := range events {
+			if err := printEvent(e); err != nil {
+				return err
+			}
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+			cs, err := client.GetAPI().GetCloudspace(ctx, org, name)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				// The SDK's errors lose the status code, so ask again to tell a deletion apart
+				if rxtspot.IsNotFound(client.CheckCloudspace(ctx, org, name)) {
+					return printEvent(cloudspaceEvent{Time: time.Now(), Type: "Normal", Object: "cloudspace/" + name, Message: "deleted"})
+				}
+				return fmt.Errorf("failed to get cloudspace %s: %w", name, err)
+			}
+			next := cloudspaceEventStates(cs)
+			for _, e := range diffEventStates(states, next, time.Now()) {
+				if err := printEvent(e); err != nil {
+					return err
+				}
+			}
+			states = next
+		}
+	},
+}
+
+func init() {
+	cloudspacesCmd.AddCommand(cloudspacesEventsCmd)
+
+	cloudspacesEventsCmd.Flags().String("name", "", "Cloudspace name (required)")
+	cloudspacesEventsCmd.Flags().String("org", "", "Organization ID")
+	cloudspacesEventsCmd.Flags().BoolP("follow", "f", false, "Keep polling and print an event for every change until interrupted")
+	cloudspacesEventsCmd.Flags().Duration("interval", readyPollInterval, "Polling interval for --follow")
+	cloudspacesEventsCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// fakeOrgNamespace is the API namespace of the "hooli" organization served by fakeAPI
+const fakeOrgNamespace = "org-test"
+
+// fakeAPI stands in for the Spot API and auth service. GET returns the object stored at the
+// request path or a 404; PATCH, POST and DELETE are recorded and succeed unless a failure
+// was queued for them.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	objects  map[string]interface{}
+	failures map[string][]int
+	requests []fakeRequest
+}
+
+// fakeRequest is a request received by fakeAPI, with its JSON body decoded
+type fakeRequest struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+}
+
+// newFakeAPI starts a fake API and points spotctl at it through a temporary config
+func newFakeAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	api := &fakeAPI{objects: map[string]interface{}{}, failures: map[string][]int{}}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serve))
+	t.Cleanup(api.Close)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte("version: 1\norg: hooli\nrefreshToken: test-refresh\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SPOTCTL_CONFIG_DIR", dir)
+	t.Setenv("SPOT_REFRESH_TOKEN", "")
+	t.Setenv("SPOT_BASE_URL", api.URL)
+	t.Setenv("SPOT_AUTH_URL", api.URL)
+	return api
+}
+
+// set stores the object served on GET path
+func (a *fakeAPI) set(path string, obj interface{}) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.objects[path] = obj
+}
+
+// fail makes the next request with method to path fail with status. Failures queued for the
+// same request are returned in order.
+func (a *fakeAPI) fail(method, path string, status int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.failures[method+" "+path] = append(a.failures[method+" "+path], status)
+}
+
+// received returns the requests with method sent to path
+func (a *fakeAPI) received(method, path string) []fakeRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var out []fakeRequest
+	for _, r := range a.requests {
+		if r.Method == method && r.Path == path {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (a *fakeAPI) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/oauth/token":
+		claims := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":4102444800}`))
+		json.NewEncoder(w).Encode(map[string]string{"id_token": "e30." + claims + ".sig"})
+		return
+	case r.URL.Path == "/apis/auth.ngpc.rxt.io/v1/organizations":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"organizations": []map[string]string{{"name": "hooli", "id": strings.ReplaceAll(fakeOrgNamespace, "-", "_")}},
+		})
+		return
+	}
+
+	req := fakeRequest{Method: r.Method, Path: r.URL.Path}
+	if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+		json.Unmarshal(data, &req.Body)
+	}
+	a.mu.Lock()
+	a.requests = append(a.requests, req)
+	obj, ok := a.objects[r.URL.Path]
+	status := 0
+	if queued := a.failures[r.Method+" "+r.URL.Path]; len(queued) > 0 {
+		status, a.failures[r.Method+" "+r.URL.Path] = queued[0], queued[1:]
+	}
+	a.mu.Unlock()
+
+	if status != 0 {
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"kind":"Status","code":%d}`, status)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Write([]byte("{}"))
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"kind":"Status","code":404,"reason":"NotFound"}`))
+		return
+	}
+	json.NewEncoder(w).Encode(obj)
+}
+
+// runCommand runs spotctl with args, discarding its stdout, and resets every flag afterwards
+// so the next run starts from the defaults
+func runCommand(t *testing.T, args ...string) error {
+	t.Helper()
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = stdout
+		devNull.Close()
+		resetFlags(rootCmd)
+	}()
+	// PersistentPreRun registers the klog flags on the global flag set, which panics the
+	// second time in the same process
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	rootCmd.SetArgs(args)
+	return rootCmd.Execute()
+}
+
+// resetFlags restores the default value of every flag of cmd and its subcommands
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// genCmd groups generators for files derived from the command tree
+var genCmd = &cobra.Command{
+	Use:    "gen",
+	Short:  "Generate files from the command tree",
+	Hidden: true,
+}
+
+// genDocsCmd represents the gen docs command
+var genDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate a Markdown command reference",
+	Long: `Write one Markdown page per command, with its usage, flags and examples, to --dir. The
+reference is generated from the live command tree, so it always matches this build.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+		// The generation date would make every regeneration a diff
+		rootCmd.DisableAutoGenTag = true
+		if err := doc.GenMarkdownTree(rootCmd, dir); err != nil {
+			return fmt.Errorf("failed to generate docs: %w", err)
+		}
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Command reference written to %s\n", dir)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(genCmd)
+	genCmd.AddCommand(genDocsCmd)
+
+	genDocsCmd.Flags().String("dir", "docs/commands", "Directory to write the Markdown files to")
+}
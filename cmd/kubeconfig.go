@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// cloudspacesKubeconfigCmd groups the commands that maintain local kubeconfig files
+var cloudspacesKubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig",
+	Short: "Maintain local kubeconfig files",
+	Long:  `Maintain kubeconfig files previously downloaded with 'cloudspaces get-config'.`,
+}
+
+// cloudspacesKubeconfigRotateCmd represents the cloudspaces kubeconfig rotate command
+var cloudspacesKubeconfigRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Refresh the credentials in a local kubeconfig",
+	Long: `Download a fresh kubeconfig for a cloudspace and copy only its user credentials into an
+existing local kubeconfig. Users are matched through the contexts of the local file that have
+the same name or cluster as a context of the fresh kubeconfig; everything else in the file,
+including other clusters and your own edits, is left untouched.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+
+		path, _ := cmd.Flags().GetString("file")
+		if path == "" {
+			path, err = kubeconfigPath("", name)
+		} else {
+			path, err = config.ExpandPath(path)
+		}
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to read kubeconfig %s (use 'cloudspaces get-config' to download it first): %w", path, err)
+		}
+		local, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read kubeconfig %s: %w", path, err)
+		}
+
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		fresh, err := client.GetAPI().GetCloudspaceConfig(cmd.Context(), org, name)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		rotated, users, err := rotateKubeconfigCredentials(local, []byte(fresh))
+		if err != nil {
+			return fmt.Errorf("failed to rotate %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, rotated, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+		}
+		fmt.Fprintf(os.Stderr, "Rotated credentials of user(s) %s in %s\n", strings.Join(users, ", "), path)
+		return nil
+	},
+}
+
+// defaultKubeconfigPath returns the kubeconfig kubectl reads by default: the first file of
+// $KUBECONFIG, or ~/.kube/config
+func defaultKubeconfigPath() string {
+	for _, p := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+		if p != "" {
+			return p
+		}
+	}
+	return filepath.Join(os.Getenv("HOME"), ".kube", "config")
+}
+
+// mergeCloudspaceKubeconfig downloads the kubeconfig of a cloudspace and merges it into the
+// default kubeconfig. With use the cloudspace's context becomes the current-context.
+func mergeCloudspaceKubeconfig(ctx context.Context, client *internal.Client, org, name string, execCred, use bool) error {
+	fresh, err := fetchKubeconfig(ctx, client, org, name, execCred)
+	if err != nil {
+		return err
+	}
+	path := defaultKubeconfigPath()
+	perm := os.FileMode(0600)
+	local, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if info, statErr := os.Stat(path); statErr == nil {
+			perm = info.Mode().Perm()
+		}
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+		}
+	default:
+		return fmt.Errorf("failed to read kubeconfig %s: %w", path, err)
+	}
+
+	merged, newContext, previous, err := mergeKubeconfig(local, fresh, use)
+	if err != nil {
+		return fmt.Errorf("failed to merge into %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, merged, perm); err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	fmt.Fprintf(os.Stderr, "Merged cloudspace %s into %s\n", name, path)
+	if use {
+		if previous == "" || previous == newContext {
+			fmt.Fprintf(os.Stderr, "Switched to context %s\n", newContext)
+		} else {
+			fmt.Fprintf(os.Stderr, "Switched to context %s (previous: %s; switch back with 'kubectl config use-context %s')\n", newContext, previous, previous)
+		}
+	}
+	return nil
+}
+
+// mergeKubeconfig adds the clusters, contexts and users of fresh to local, replacing entries
+// with the same name. It returns the merged file, the context of fresh (its current-context,
+// or its first context) and the current-context local had before. With use that context
+// becomes the current-context. An empty local yields fresh itself.
+func mergeKubeconfig(local, fresh []byte, use bool) ([]byte, string, string, error) {
+	var freshDoc, localDoc yaml.Node
+	if err := yaml.Unmarshal(fresh, &freshDoc); err != nil {
+		return nil, "", "", fmt.Errorf("failed to parse downloaded kubeconfig: %w", err)
+	}
+	if len(freshDoc.Content) == 0 {
+		return nil, "", "", fmt.Errorf("downloaded kubeconfig is empty")
+	}
+	freshRoot := freshDoc.Content[0]
+	newContext := scalarValue(mappingValue(freshRoot, "current-context"))
+	if newContext == "" {
+		if contexts := kubeconfigContexts(freshRoot); len(contexts) > 0 {
+			newContext = contexts[0].name
+		}
+	}
+	if use && newContext == "" {
+		return nil, "", "", fmt.Errorf("downloaded kubeconfig has no context")
+	}
+
+	if err := yaml.Unmarshal(local, &localDoc); err != nil {
+		return nil, "", "", fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	previous := ""
+	if len(localDoc.Content) == 0 {
+		localDoc = freshDoc
+	} else {
+		localRoot := localDoc.Content[0]
+		if localRoot.Kind != yaml.MappingNode {
+			return nil, "", "", fmt.Errorf("kubeconfig is not a mapping")
+		}
+		previous = scalarValue(mappingValue(localRoot, "current-context"))
+		for _, key := range []string{"clusters", "contexts", "users"} {
+			mergeNamedList(localRoot, mappingValue(freshRoot, key), key)
+		}
+	}
+
+	if use {
+		setMappingValue(localDoc.Content[0], "current-context", newContext)
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&localDoc); err != nil {
+		return nil, "", "", fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+	return out.Bytes(), newContext, previous, nil
+}
+
+// mergeNamedList copies the entries of list into the list under key of root, replacing the
+// entries with the same name and appending the others
+func mergeNamedList(root, list *yaml.Node, key string) {
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	target := mappingValue(root, key)
+	if target == nil || target.Kind != yaml.SequenceNode {
+		target = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setMappingNode(root, key, target)
+	}
+	for _, item := range list.Content {
+		name := scalarValue(mappingValue(item, "name"))
+		replaced := false
+		for i, existing := range target.Content {
+			if scalarValue(mappingValue(existing, "name")) == name {
+				target.Content[i] = item
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			target.Content = append(target.Content, item)
+		}
+	}
+}
+
+// setMappingValue sets key of a mapping node to a string scalar
+func setMappingValue(node *yaml.Node, key, value string) {
+	setMappingNode(node, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+}
+
+// setMappingNode sets key of a mapping node to value, adding the key when it is missing
+func setMappingNode(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// kubeconfigContext is the part of a kubeconfig context used to match users between files
+type kubeconfigContext struct {
+	name    string
+	cluster string
+	user    string
+}
+
+// rotateKubeconfigCredentials copies the user credentials of fresh into the users of local
+// that belong to a context with the same name or cluster. It returns the updated file and the
+// names of the rotated users. Comments and ordering of local are preserved.
+func rotateKubeconfigCredentials(local, fresh []byte) ([]byte, []string, error) {
+	var freshDoc, localDoc yaml.Node
+	if err := yaml.Unmarshal(fresh, &freshDoc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse downloaded kubeconfig: %w", err)
+	}
+	if err := yaml.Unmarshal(local, &localDoc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if len(freshDoc.Content) == 0 || len(localDoc.Content) == 0 {
+		return nil, nil, fmt.Errorf("kubeconfig is empty")
+	}
+	freshRoot, localRoot := freshDoc.Content[0], localDoc.Content[0]
+
+	// Credentials of the fresh kubeconfig by context name and by cluster name
+	freshUsers := namedEntries(freshRoot, "users", "user")
+	byContext := map[string]*yaml.Node{}
+	byCluster := map[string]*yaml.Node{}
+	for _, c := range kubeconfigContexts(freshRoot) {
+		if cred, ok := freshUsers[c.user]; ok {
+			byContext[c.name] = cred
+			byCluster[c.cluster] = cred
+		}
+	}
+
+	localUsers := namedEntries(localRoot, "users", "user")
+	rotated := map[string]bool{}
+	for _, c := range kubeconfigContexts(localRoot) {
+		cred, ok := byContext[c.name]
+		if !ok {
+			cred, ok = byCluster[c.cluster]
+		}
+		target, exists := localUsers[c.user]
+		if !ok || !exists || rotated[c.user] {
+			continue
+		}
+		*target = *cred
+		rotated[c.user] = true
+	}
+	if len(rotated) == 0 {
+		return nil, nil, fmt.Errorf("no context matches a context or cluster of the cloudspace kubeconfig")
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&localDoc); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+	users := make([]string, 0, len(rotated))
+	for u := range rotated {
+		users = append(users, u)
+	}
+	sort.Strings(users)
+	return out.Bytes(), users, nil
+}
+
+// kubeconfigContexts lists the contexts of a kubeconfig mapping node
+func kubeconfigContexts(root *yaml.Node) []kubeconfigContext {
+	var contexts []kubeconfigContext
+	for name, ctx := range namedEntries(root, "contexts", "context") {
+		contexts = append(contexts, kubeconfigContext{
+			name:    name,
+			cluster: scalarValue(mappingValue(ctx, "cluster")),
+			user:    scalarValue(mappingValue(ctx, "user")),
+		})
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].name < contexts[j].name })
+	return contexts
+}
+
+// namedEntries maps the name of every entry of the list under key (e.g. users) to the node
+// holding its body (e.g. the entry's user mapping)
+func namedEntries(root *yaml.Node, key, body string) map[string]*yaml.Node {
+	entries := map[string]*yaml.Node{}
+	list := mappingValue(root, key)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return entries
+	}
+	for _, item := range list.Content {
+		if b := mappingValue(item, body); b != nil {
+			entries[scalarValue(mappingValue(item, "name"))] = b
+		}
+	}
+	return entries
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the value of a scalar node, or "" for nil and non-scalar nodes
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+func init() {
+	cloudspacesCmd.AddCommand(cloudspacesKubeconfigCmd)
+	cloudspacesKubeconfigCmd.AddCommand(cloudspacesKubeconfigRotateCmd)
+
+	cloudspacesKubeconfigRotateCmd.Flags().String("name", "", "Cloudspace name (required)")
+	cloudspacesKubeconfigRotateCmd.Flags().String("org", "", "Organization ID")
+	cloudspacesKubeconfigRotateCmd.Flags().String("file", "", "Kubeconfig file to update (default: ~/.kube/<cloudspace_name>.yaml)")
+	cloudspacesKubeconfigRotateCmd.MarkFlagRequired("name")
+}
@@ -3,10 +3,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"maps"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/fatih/color"
 	"github.com/google/uuid"
 	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
 	"github.com/rackspace-spot/spotctl/internal"
@@ -43,6 +47,167 @@ func parseCustomAnnotations(annotationsStr string) (map[string]string, error) {
 	return parseCustomLabels(annotationsStr) // Same parsing logic as labels
 }
 
+// bidFromStrategy computes a bid for serverClass in region. The "min" strategy bids the
+// lowest accepted price, which is never below the market price; "ondemand" bids the
+// on-demand price, raised to the minimum bid if that is higher.
+func bidFromStrategy(ctx context.Context, client *internal.Client, region, serverClass, strategy string) (string, error) {
+	prices, err := client.RegionPricing(ctx, region)
+	if err != nil {
+		return "", err
+	}
+	price, ok := prices[serverClass]
+	if !ok {
+		return "", fmt.Errorf("server class %s not found in region %s", serverClass, region)
+	}
+	bid := price.EffectiveMinBid()
+	if strategy == "ondemand" {
+		bid = max(bid, price.OnDemand)
+	}
+	if bid <= 0 {
+		return "", fmt.Errorf("no pricing available for server class %s in region %s", serverClass, region)
+	}
+	return strconv.FormatFloat(bid, 'f', -1, 64), nil
+}
+
+// spotPoolRow is the table view of a spot node pool
+type spotPoolRow struct {
+	Name        string `json:"name"`
+	ServerClass string `json:"serverclass"`
+	Desired     int    `json:"desired"`
+	Autoscaling string `json:"autoscaling"`
+	Ready       string `json:"ready"`
+	BidPrice    string `json:"bidprice"`
+	MarketPrice string `json:"marketprice"`
+	VsMarket    string `json:"vsmarket"`
+}
+
+// marketPosition compares a bid with the current market price of its server class: "above"
+// and "at" bids keep their nodes, "below" bids are outbid and should be raised. It returns "-"
+// when either price is unknown.
+func marketPosition(bid, market float64) string {
+	switch {
+	case bid <= 0 || market <= 0:
+		return "-"
+	case bid > market:
+		return "above"
+	case bid < market:
+		return "below"
+	default:
+		return "at"
+	}
+}
+
+// onDemandPoolRow is the table view of an on-demand node pool
+type onDemandPoolRow struct {
+	Name        string `json:"name"`
+	ServerClass string `json:"serverclass"`
+	Desired     int    `json:"desired"`
+	Autoscaling string `json:"autoscaling"`
+	Ready       string `json:"ready"`
+	Status      string `json:"status"`
+}
+
+// poolTotals accumulates the --totals rollup of a node pool table
+type poolTotals struct {
+	pools    int
+	desired  int
+	ready    int
+	hourly   float64
+	unpriced int
+}
+
+// add counts one pool with desired nodes at an hourly price per node, where a price of 0
+// means the pool's pricing is unknown
+func (t *poolTotals) add(desired int, ready int, price float64) {
+	t.pools++
+	t.desired += desired
+	t.ready += ready
+	if price > 0 {
+		t.hourly += price * float64(desired)
+	} else {
+		t.unpriced++
+	}
+}
+
+// label is the name column of the totals row
+func (t *poolTotals) label() string {
+	return fmt.Sprintf("TOTAL (%d pools)", t.pools)
+}
+
+// summary returns the estimated cost line printed under the totals row
+func (t *poolTotals) summary() []string {
+	line := fmt.Sprintf("Estimated cost: $%.3f/hour, about $%.2f/month", t.hourly, t.hourly*hoursPerMonth)
+	if t.unpriced > 0 {
+		line += fmt.Sprintf(" (excluding %d pool(s) without pricing)", t.unpriced)
+	}
+	return []string{line}
+}
+
+// autoscalingRange renders autoscaling bounds as "min-max", or "-" when autoscaling is off
+func autoscalingRange(enabled bool, minNodes, maxNodes int64) string {
+	if !enabled {
+		return "-"
+	}
+	return fmt.Sprintf("%d-%d", minNodes, maxNodes)
+}
+
+// parseDesired parses a desired node count. Surrounding whitespace is ignored; empty,
+// non-numeric and negative values are rejected, and so is 0 unless allowZero is set, which
+// is the case for updates that scale a pool down to no nodes.
+func parseDesired(value string, allowZero bool) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("desired is required")
+	}
+	desired, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("desired must be a whole number, got %q", value)
+	}
+	switch {
+	case desired < 0:
+		return 0, fmt.Errorf("desired must not be negative, got %d", desired)
+	case desired == 0 && !allowZero:
+		return 0, fmt.Errorf("desired must be at least 1")
+	}
+	return desired, nil
+}
+
+// autoscalingFlags reads --min and --max. Both must be given together and satisfy
+// min <= max; when desired is known it must lie within the range.
+func autoscalingFlags(cmd *cobra.Command, desired int, desiredKnown bool) (minNodes, maxNodes int, enabled bool, err error) {
+	minSet, maxSet := cmd.Flags().Changed("min"), cmd.Flags().Changed("max")
+	if !minSet && !maxSet {
+		return 0, 0, false, nil
+	}
+	if minSet != maxSet {
+		return 0, 0, false, fmt.Errorf("--min and --max must be set together")
+	}
+	minNodes, _ = cmd.Flags().GetInt("min")
+	maxNodes, _ = cmd.Flags().GetInt("max")
+	if minNodes < 0 || minNodes > maxNodes {
+		return 0, 0, false, fmt.Errorf("invalid autoscaling range: --min %d must be between 0 and --max %d", minNodes, maxNodes)
+	}
+	if desiredKnown && (desired < minNodes || desired > maxNodes) {
+		return 0, 0, false, fmt.Errorf("desired %d must be between --min %d and --max %d", desired, minNodes, maxNodes)
+	}
+	return minNodes, maxNodes, true, nil
+}
+
+// patchDesiredZero scales a node pool to zero. The SDK update bodies omit a zero desired, so
+// it is sent as a merge patch, as pause does.
+func patchDesiredZero(ctx context.Context, client *internal.Client, org, name string, spot bool) error {
+	if err := client.PatchNodePoolSpec(ctx, org, name, spot, map[string]interface{}{"desired": 0}); err != nil {
+		return fmt.Errorf("failed to set desired to 0: %w", err)
+	}
+	return nil
+}
+
+// bidValue parses a bid price string such as "$0.08" for numeric comparison.
+// Unparseable bids sort first.
+func bidValue(bid string) float64 {
+	return internal.ParsePrice(bid)
+}
+
 // nodepoolsCmd represents the nodepools command
 var nodepoolsCmd = &cobra.Command{
 	Use:     "nodepools",
@@ -69,6 +234,17 @@ func init() {
 	rootCmd.AddCommand(nodepoolsCmd)
 	nodepoolsCmd.AddCommand(spotCmd)
 	nodepoolsCmd.AddCommand(ondemandCmd)
+	nodepoolsCmd.AddCommand(nodepoolsListCmd)
+
+	// Flags for the unified node pool list
+	nodepoolsListCmd.Flags().String("org", "", "Organization ID")
+	nodepoolsListCmd.Flags().String("cloudspace", "", "Cloudspace name (default: all cloudspaces in the organization)")
+	nodepoolsListCmd.Flags().Bool("all-orgs", false, "List node pools across every accessible organization")
+	nodepoolsListCmd.Flags().Int("parallelism", defaultParallelism, "Maximum number of concurrent API requests")
+	nodepoolsListCmd.Flags().String("label-selector", "", "Filter by labels (e.g. env=prod,team!=infra)")
+	nodepoolsListCmd.Flags().Bool("only-spot", false, "List only spot node pools; on-demand pools are not fetched")
+	nodepoolsListCmd.Flags().Bool("only-ondemand", false, "List only on-demand node pools; spot pools are not fetched")
+	nodepoolsListCmd.Flags().Bool("stream", false, "Write pools as pages arrive instead of sorting the complete listing (json output becomes newline-delimited JSON)")
 
 	// Add spot subcommands
 	spotCmd.AddCommand(spotListCmd)
@@ -84,14 +260,18 @@ func init() {
 	ondemandCmd.AddCommand(ondemandUpdateCmd)
 	ondemandCmd.AddCommand(ondemandDeleteCmd)
 
-	spotGetCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID) (required)")
-	spotGetCmd.MarkFlagRequired("name")
+	spotGetCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID)")
+	spotGetCmd.Flags().String("cloudspace", "", "Cloudspace to search for --pool-name")
+	spotGetCmd.Flags().String("pool-name", "", "Select the pool by its \"name\" custom label or a unique UUID prefix instead of --name (requires --cloudspace)")
 
 	// Flags for spot list
 	spotListCmd.Flags().String("org", "", "Organization (required)")
 	//spotListCmd.MarkFlagRequired("org")
 	spotListCmd.Flags().String("cloudspace", "", "Cloudspace name (required)")
 	spotListCmd.MarkFlagRequired("cloudspace")
+	spotListCmd.Flags().String("sort-by", "", "Sort node pools by bid, desired, name or ready")
+	spotListCmd.Flags().String("label-selector", "", "Filter by labels (e.g. env=prod,team!=infra)")
+	spotListCmd.Flags().Bool("totals", false, "Print a row with the total pools, desired and ready nodes and the estimated hourly cost (table output only)")
 
 	// Flags for spot create
 	// spotCreateCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID) (required)")
@@ -99,15 +279,20 @@ func init() {
 	spotCreateCmd.Flags().String("cloudspace", "", "Cloudspace name (required)")
 	spotCreateCmd.Flags().String("serverclass", "", "Server class (required)")
 	spotCreateCmd.Flags().String("desired", "", "Desired number of nodes (required)")
-	spotCreateCmd.Flags().String("bidprice", "", "Maximum bid price (required)")
+	spotCreateCmd.Flags().String("bidprice", "", "Maximum bid price (required unless --bid-strategy is set)")
+	spotCreateCmd.Flags().String("bid-strategy", "", "Compute the bid from current pricing instead of --bidprice: min (lowest accepted bid) or ondemand (the on-demand price, for the best chance of keeping nodes)")
 	spotCreateCmd.Flags().String("custom-labels", "", "Custom Labels to be added on the spot nodepool. eg: --custom-labels key1=value1,key2=value2")
 	spotCreateCmd.Flags().String("custom-annotations", "", "Custom Annotations to be added to the spot nodepool. eg: --custom-annotations key1=value1,key2=value2")
+	addPorcelainFlag(spotCreateCmd)
 	spotCreateCmd.Flags().String("custom-taints", "", "Custom taints to be added to the spot nodepool. eg: --custom-taints key1=value1,key2=value2")
+	spotCreateCmd.Flags().Int("min", 0, "Minimum number of nodes when autoscaling (requires --max)")
+	spotCreateCmd.Flags().Int("max", 0, "Maximum number of nodes when autoscaling (requires --min)")
+	spotCreateCmd.Flags().Float64("min-bid-buffer", 0, "Automatically raise a bid below the server class minimum to the minimum plus this amount")
 	spotCreateCmd.MarkFlagRequired("name")
 	spotCreateCmd.MarkFlagRequired("cloudspace")
 	spotCreateCmd.MarkFlagRequired("serverclass")
+	spotCreateCmd.RegisterFlagCompletionFunc("serverclass", completeServerClasses)
 	spotCreateCmd.MarkFlagRequired("desired")
-	spotCreateCmd.MarkFlagRequired("bidprice")
 
 	spotUpdateCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID) (required)")
 	spotUpdateCmd.Flags().String("cloudspace", "", "Cloudspace name (required)")
@@ -117,20 +302,33 @@ func init() {
 	spotUpdateCmd.Flags().String("custom-labels", "", "Custom Labels to be added on the spot nodepool. eg: --custom-labels key1=value1,key2=value2")
 	spotUpdateCmd.Flags().String("custom-annotations", "", "Custom Annotations to be added to the spot nodepool. eg: --custom-annotations key1=value1,key2=value2")
 	spotUpdateCmd.Flags().String("custom-taints", "", "Custom taints to be added to the spot nodepool. eg: --custom-taints key1=value1,key2=value2")
+	spotUpdateCmd.Flags().Int("min", 0, "Minimum number of nodes when autoscaling (requires --max)")
+	spotUpdateCmd.Flags().Int("max", 0, "Maximum number of nodes when autoscaling (requires --min)")
+	spotUpdateCmd.Flags().Bool("force", false, "Submit the update even when nothing differs from the current node pool, and overwrite concurrent changes to it")
+	addRetryOnConflictFlag(spotUpdateCmd, "re-reading the node pool and re-applying the update")
 	spotUpdateCmd.MarkFlagRequired("name")
 	spotUpdateCmd.MarkFlagRequired("cloudspace")
 
-	spotDeleteCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID) (required)")
-	spotDeleteCmd.MarkFlagRequired("name")
+	spotDeleteCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID)")
+	spotDeleteCmd.Flags().String("cloudspace", "", "Cloudspace to search for --pool-name")
+	spotDeleteCmd.Flags().String("pool-name", "", "Select the pool by its \"name\" custom label or a unique UUID prefix instead of --name (requires --cloudspace)")
+	spotDeleteCmd.Flags().Bool("all", false, "Delete every spot node pool in --cloudspace")
+	spotDeleteCmd.Flags().Int("parallelism", defaultParallelism, "Maximum number of concurrent deletes with --all")
 	spotDeleteCmd.Flags().BoolP("yes", "y", false, "Automatic yes to prompts; assume \"yes\" as answer")
+	spotDeleteCmd.Flags().Bool("wait-for-delete", false, "Wait until the deletion has completed")
+	spotDeleteCmd.Flags().Duration("timeout", 10*time.Minute, "Maximum time to wait with --wait-for-delete")
+	spotDeleteCmd.Flags().Bool("dry-run", false, "Verify the node pool exists and print what would be deleted without deleting it")
 
 	// Flags for ondemand list
 	ondemandListCmd.Flags().String("org", "", "Organization ID")
 	ondemandListCmd.Flags().String("cloudspace", "", "Cloudspace name (required)")
+	ondemandListCmd.Flags().String("label-selector", "", "Filter by labels (e.g. env=prod,team!=infra)")
+	ondemandListCmd.Flags().Bool("totals", false, "Print a row with the total pools, desired and ready nodes and the estimated hourly cost (table output only)")
 	ondemandListCmd.MarkFlagRequired("cloudspace")
 
-	ondemandGetCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID) (required)")
-	ondemandGetCmd.MarkFlagRequired("name")
+	ondemandGetCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID)")
+	ondemandGetCmd.Flags().String("cloudspace", "", "Cloudspace to search for --pool-name")
+	ondemandGetCmd.Flags().String("pool-name", "", "Select the pool by its \"name\" custom label or a unique UUID prefix instead of --name (requires --cloudspace)")
 
 	// Flags for ondemand create
 	// ondemandCreateCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID) (required)")
@@ -141,9 +339,16 @@ func init() {
 	ondemandCreateCmd.Flags().String("custom-labels", "", "Custom Labels to be added on the spot nodepool. eg: --custom-labels key1=value1,key2=value2")
 	ondemandCreateCmd.Flags().String("custom-annotations", "", "Custom Annotations to be added to the spot nodepool. eg: --custom-annotations key1=value1,key2=value2")
 	ondemandCreateCmd.Flags().String("custom-taints", "", "Custom taints to be added to the spot nodepool. eg: --custom-taints key1=value1,key2=value2")
+	ondemandCreateCmd.Flags().Int("min", 0, "Minimum number of nodes when autoscaling (requires --max)")
+	ondemandCreateCmd.Flags().Int("max", 0, "Maximum number of nodes when autoscaling (requires --min)")
+	ondemandCreateCmd.Flags().Bool("price-check", false, "Show the projected hourly and monthly cost and ask for confirmation before creating")
+	ondemandCreateCmd.Flags().Float64("max-hourly", 0, "Ask for confirmation only when the projected cost in $/hour exceeds this amount (implies --price-check)")
+	ondemandCreateCmd.Flags().BoolP("yes", "y", false, "Automatic yes to prompts; assume \"yes\" as answer")
+	addPorcelainFlag(ondemandCreateCmd)
 	ondemandCreateCmd.MarkFlagRequired("name")
 	ondemandCreateCmd.MarkFlagRequired("cloudspace")
 	ondemandCreateCmd.MarkFlagRequired("serverclass")
+	ondemandCreateCmd.RegisterFlagCompletionFunc("serverclass", completeServerClasses)
 	ondemandCreateCmd.MarkFlagRequired("desired")
 
 	ondemandUpdateCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID) (required)")
@@ -153,12 +358,21 @@ func init() {
 	ondemandUpdateCmd.Flags().String("custom-labels", "", "Custom Labels to be added on the spot nodepool. eg: --custom-labels key1=value1,key2=value2")
 	ondemandUpdateCmd.Flags().String("custom-annotations", "", "Custom Annotations to be added to the spot nodepool. eg: --custom-annotations key1=value1,key2=value2")
 	ondemandUpdateCmd.Flags().String("custom-taints", "", "Custom taints to be added to the spot nodepool. eg: --custom-taints key1=value1,key2=value2")
+	ondemandUpdateCmd.Flags().Int("min", 0, "Minimum number of nodes when autoscaling (requires --max)")
+	ondemandUpdateCmd.Flags().Int("max", 0, "Maximum number of nodes when autoscaling (requires --min)")
+	addRetryOnConflictFlag(ondemandUpdateCmd, "re-applying the update")
 	ondemandUpdateCmd.MarkFlagRequired("name")
 	ondemandUpdateCmd.MarkFlagRequired("cloudspace")
 
-	ondemandDeleteCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID) (required)")
-	ondemandDeleteCmd.MarkFlagRequired("name")
+	ondemandDeleteCmd.Flags().String("name", "", "Node pool name (Note: It should be a valid lower case UUID)")
+	ondemandDeleteCmd.Flags().String("cloudspace", "", "Cloudspace to search for --pool-name")
+	ondemandDeleteCmd.Flags().String("pool-name", "", "Select the pool by its \"name\" custom label or a unique UUID prefix instead of --name (requires --cloudspace)")
+	ondemandDeleteCmd.Flags().Bool("all", false, "Delete every ondemand node pool in --cloudspace")
+	ondemandDeleteCmd.Flags().Int("parallelism", defaultParallelism, "Maximum number of concurrent deletes with --all")
 	ondemandDeleteCmd.Flags().BoolP("yes", "y", false, "Automatic yes to prompts; assume \"yes\" as answer")
+	ondemandDeleteCmd.Flags().Bool("wait-for-delete", false, "Wait until the deletion has completed")
+	ondemandDeleteCmd.Flags().Duration("timeout", 10*time.Minute, "Maximum time to wait with --wait-for-delete")
+	ondemandDeleteCmd.Flags().Bool("dry-run", false, "Verify the node pool exists and print what would be deleted without deleting it")
 
 }
 
@@ -176,14 +390,9 @@ var spotListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
-		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
@@ -191,10 +400,97 @@ var spotListCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		pools, err := client.GetAPI().ListSpotNodePools(context.Background(), org, cloudspace)
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		switch sortBy {
+		case "", "bid", "desired", "name", "ready":
+		default:
+			return fmt.Errorf("invalid --sort-by %q (must be bid, desired, name or ready)", sortBy)
+		}
+		selectorStr, _ := cmd.Flags().GetString("label-selector")
+		selector, err := parseLabelSelector(selectorStr)
+		if err != nil {
+			return err
+		}
+
+		pools, err := client.ListSpotNodePools(context.Background(), org, cloudspace)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
+		if len(selector) > 0 {
+			filtered := pools[:0]
+			for _, p := range pools {
+				if selector.matches(p.CustomLabels) {
+					filtered = append(filtered, p)
+				}
+			}
+			pools = filtered
+		}
+
+		if sortBy != "" {
+			sort.SliceStable(pools, func(i, j int) bool {
+				a, b := pools[i], pools[j]
+				switch sortBy {
+				case "bid":
+					if bidValue(a.BidPrice) != bidValue(b.BidPrice) {
+						return bidValue(a.BidPrice) < bidValue(b.BidPrice)
+					}
+				case "desired":
+					if a.Desired != b.Desired {
+						return a.Desired < b.Desired
+					}
+				case "ready":
+					if a.WonCount != b.WonCount {
+						return a.WonCount < b.WonCount
+					}
+				}
+				return a.Name < b.Name
+			})
+		}
+
+		// In table mode show a focused view of desired vs ready nodes and the current bid
+		// against the market price of each pool's server class. Spot nodes are billed at the
+		// market price, so --totals estimates the cost from it, falling back to the bid.
+		if strings.EqualFold(outputFormat, "table") {
+			showTotals, _ := cmd.Flags().GetBool("totals")
+			var totals poolTotals
+			var prices map[string]internal.ServerClassPrice
+			if len(pools) > 0 {
+				prices, err = cloudspacePricing(cmd.Context(), client, org, cloudspace)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: market prices unavailable: %v\n", err)
+				}
+			}
+			rows := []spotPoolRow{}
+			for _, p := range pools {
+				market := prices[p.ServerClass].Market
+				marketStr := "-"
+				if market > 0 {
+					marketStr = strconv.FormatFloat(market, 'f', -1, 64)
+				}
+				price := market
+				if price <= 0 {
+					price = bidValue(p.BidPrice)
+				}
+				totals.add(p.Desired, p.WonCount, price)
+				rows = append(rows, spotPoolRow{
+					Name:        p.Name,
+					ServerClass: p.ServerClass,
+					Desired:     p.Desired,
+					Autoscaling: autoscalingRange(p.Autoscaling.Enabled, p.Autoscaling.MinNodes, p.Autoscaling.MaxNodes),
+					Ready:       strconv.Itoa(p.WonCount),
+					BidPrice:    p.BidPrice,
+					MarketPrice: marketStr,
+					VsMarket:    marketPosition(bidValue(p.BidPrice), market),
+				})
+			}
+			if showTotals {
+				return internal.OutputDataWithTotals(rows, internal.TableTotals{
+					Row:     spotPoolRow{Name: totals.label(), Desired: totals.desired, Ready: strconv.Itoa(totals.ready)},
+					Summary: totals.summary(),
+				}, outputFormat)
+			}
+			return internal.OutputData(rows, outputFormat)
+		}
 
 		return internal.OutputData(pools, outputFormat)
 	},
@@ -206,22 +502,17 @@ var spotGetCmd = &cobra.Command{
 	Short: "Get spot node pool",
 	Long:  `Get a spot node pool in a org.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name, _ := cmd.Flags().GetString("name")
-		if name == "" {
-			return fmt.Errorf("name is required")
-		}
 		cfg, err := config.GetCLIEssentials(cmd)
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		name, err := resolvePoolName(context.Background(), cmd, cfg, org, true)
+		if err != nil {
+			return err
 		}
 
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
@@ -229,7 +520,7 @@ var spotGetCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		pool, err := client.GetAPI().GetSpotNodePool(context.Background(), org, name)
+		pool, err := client.GetSpotNodePool(context.Background(), org, name)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
@@ -244,35 +535,43 @@ var spotDeleteCmd = &cobra.Command{
 	Short: "Delete spot node pools",
 	Long:  `Delete spot node pools in a org.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name, _ := cmd.Flags().GetString("name")
-		if name == "" {
-			return fmt.Errorf("name is required")
-		}
 
 		cfg, err := config.GetCLIEssentials(cmd)
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			return deleteAllPools(cmd, cfg, org, true)
+		}
+		name, err := resolvePoolName(context.Background(), cmd, cfg, org, true)
+		if err != nil {
+			return err
 		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+			pool, err := client.GetSpotNodePool(context.Background(), org, name)
+			if err != nil {
+				if rxtspot.IsNotFound(err) {
+					return fmt.Errorf("spot node pool '%s' not found", name)
+				}
+				return fmt.Errorf("%w", err)
+			}
+			return reportDryRunDeleted(cmd, "spotnodepool", name, fmt.Sprintf("spot node pool - %s (cloudspace %s) would be deleted (dry run)", name, pool.Cloudspace))
 		}
 
 		yes, _ := cmd.Flags().GetBool("yes")
 		if !yes {
 			// Interactive prompt
-			prompt := color.New(color.FgYellow).PrintfFunc()
-			prompt("Are you sure you want to delete spot nodepool '%s'? (y/N): ", name)
-
-			var response string
-			_, err := fmt.Scanln(&response)
-			if err != nil || (response != "y" && response != "Y") {
-				fmt.Println("Aborted.")
+			if !confirmYesNo("Are you sure you want to delete spot nodepool '%s'? (y/N): ", name) {
 				return nil
 			}
 		}
@@ -285,9 +584,17 @@ var spotDeleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
-		fmt.Printf("spot node pool - %s deleted successfully \n", name)
-
-		return nil
+		if wait, _ := cmd.Flags().GetBool("wait-for-delete"); wait {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			err := waitForDeletion(cmd.Context(), fmt.Sprintf("spot node pool '%s'", name), timeout, func(ctx context.Context) error {
+				_, err := client.GetSpotNodePool(ctx, org, name)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return reportDeleted(cmd, "spotnodepool", name, fmt.Sprintf("spot node pool - %s deleted successfully", name))
 	},
 }
 
@@ -303,24 +610,32 @@ var spotCreateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
-		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 		cloudspace, _ := cmd.Flags().GetString("cloudspace")
 		serverClass, _ := cmd.Flags().GetString("serverclass")
 		desiredStr, _ := cmd.Flags().GetString("desired")
 		bidPrice, _ := cmd.Flags().GetString("bidprice")
+		bidStrategy, _ := cmd.Flags().GetString("bid-strategy")
 		customLabelsStr, _ := cmd.Flags().GetString("custom-labels")
 		customAnnotationsStr, _ := cmd.Flags().GetString("custom-annotations")
 
-		if name == "" || cloudspace == "" || serverClass == "" || desiredStr == "" || bidPrice == "" {
-			return fmt.Errorf("name, cloudspace, serverclass, desired, and bidprice are required")
+		if name == "" || cloudspace == "" || serverClass == "" || desiredStr == "" {
+			return fmt.Errorf("name, cloudspace, serverclass and desired are required")
+		}
+		porcelain, err := porcelainVersion(cmd)
+		if err != nil {
+			return err
+		}
+		if (bidPrice == "") == (bidStrategy == "") {
+			return fmt.Errorf("exactly one of --bidprice or --bid-strategy must be set")
+		}
+		switch bidStrategy {
+		case "", "min", "ondemand":
+		default:
+			return fmt.Errorf("invalid --bid-strategy %q (must be min or ondemand)", bidStrategy)
 		}
 
 		// Parse custom labels
@@ -335,15 +650,58 @@ var spotCreateCmd = &cobra.Command{
 			return fmt.Errorf("invalid custom-annotations format: %w", err)
 		}
 
-		desired, err := strconv.Atoi(desiredStr)
+		desired, err := parseDesired(desiredStr, false)
 		if err != nil {
-			return fmt.Errorf("desired must be a valid integer: %w", err)
+			return err
+		}
+		minNodes, maxNodes, autoscale, err := autoscalingFlags(cmd, desired, true)
+		if err != nil {
+			return err
 		}
 
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
+		if err := validateServerClass(context.Background(), client, org, cloudspace, serverClass); err != nil {
+			return err
+		}
+
+		// Derive the bid from current pricing when a strategy is given
+		if bidStrategy != "" {
+			cs, err := client.GetCloudspace(context.Background(), org, cloudspace)
+			if err != nil {
+				return fmt.Errorf("failed to get cloudspace %s: %w", cloudspace, err)
+			}
+			bidPrice, err = bidFromStrategy(context.Background(), client, cs.Region, serverClass, bidStrategy)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Using %s bid strategy: $%s\n", bidStrategy, bidPrice)
+		}
+
+		// Raise a bid below the server class minimum when --min-bid-buffer is set
+		if cmd.Flags().Changed("min-bid-buffer") {
+			buffer, _ := cmd.Flags().GetFloat64("min-bid-buffer")
+			cs, err := client.GetCloudspace(context.Background(), org, cloudspace)
+			if err != nil {
+				return fmt.Errorf("failed to get cloudspace %s: %w", cloudspace, err)
+			}
+			minBids, err := getMinBidPrices(context.Background(), client, cs.Region)
+			if err != nil {
+				return err
+			}
+			if minBid, ok := minBids[serverClass]; ok {
+				adjusted, raised, err := raiseBidToMinimum(bidPrice, minBid, buffer)
+				if err != nil {
+					return err
+				}
+				if raised {
+					fmt.Fprintf(os.Stderr, "Raised bid from $%s to $%s (minimum: $%s)\n", bidPrice, adjusted, minBid)
+					bidPrice = adjusted
+				}
+			}
+		}
 
 		pool := &rxtspot.SpotNodePool{
 			Name:              name,
@@ -355,6 +713,11 @@ var spotCreateCmd = &cobra.Command{
 			CustomLabels:      customLabels,
 			CustomAnnotations: customAnnotations,
 		}
+		if autoscale {
+			pool.Autoscaling.Enabled = true
+			pool.Autoscaling.MinNodes = int64(minNodes)
+			pool.Autoscaling.MaxNodes = int64(maxNodes)
+		}
 
 		err = client.GetAPI().CreateSpotNodePool(context.Background(), org, *pool)
 		if err != nil {
@@ -365,12 +728,45 @@ var spotCreateCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		fmt.Printf("spot nodepool - %s created successfully \n", pool.Name)
+		if porcelain != "" {
+			return writePorcelainPool(os.Stdout, pool.Name, pool.Status, pool.ServerClass, pool.Desired, pool.BidPrice)
+		}
+		fmt.Fprintf(os.Stderr, "spot nodepool - %s created successfully \n", pool.Name)
 
 		return internal.OutputData(pool, outputFormat)
 	},
 }
 
+// fieldChange records the old and new value of a node pool field changed by an update
+type fieldChange struct {
+	Field string `json:"field" yaml:"field"`
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+}
+
+func (c fieldChange) String() string {
+	return fmt.Sprintf("%s %s -> %s", c.Field, c.From, c.To)
+}
+
+// spotUpdateResult is the output of 'spot update': the submitted pool and what it changed
+type spotUpdateResult struct {
+	rxtspot.SpotNodePool `yaml:",inline"`
+	Changes              []fieldChange `json:"changes" yaml:"changes"`
+}
+
+// formatKeyValues renders labels or annotations as sorted "key=value" pairs, or "-" when empty
+func formatKeyValues(m map[string]string) string {
+	if len(m) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
 // spotUpdateCmd represents the spot update command
 var spotUpdateCmd = &cobra.Command{
 	Use:   "update",
@@ -382,14 +778,9 @@ var spotUpdateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
-		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 		cloudspace, _ := cmd.Flags().GetString("cloudspace")
 		desiredStr, _ := cmd.Flags().GetString("desired")
@@ -414,9 +805,8 @@ var spotUpdateCmd = &cobra.Command{
 		}
 		var desired int
 		if desiredStr != "" {
-			desired, err = strconv.Atoi(desiredStr)
-			if err != nil {
-				return fmt.Errorf("desired must be a valid integer: %w", err)
+			if desired, err = parseDesired(desiredStr, true); err != nil {
+				return err
 			}
 		}
 
@@ -425,6 +815,70 @@ var spotUpdateCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
+		// Remember the version the comparison below is based on, so that a concurrent update by
+		// someone else is rejected instead of silently overwritten. It is read first: a change
+		// between the two reads then causes a spurious conflict rather than a lost update.
+		force, _ := cmd.Flags().GetBool("force")
+		retries, err := conflictRetries(cmd)
+		if err != nil {
+			return err
+		}
+		var resourceVersion string
+		if !force {
+			resourceVersion, err = client.NodePoolResourceVersion(cmd.Context(), org, name, true)
+			if err != nil && !rxtspot.IsNotFound(err) {
+				return fmt.Errorf("failed to get current spot node pool: %w", err)
+			}
+		}
+
+		// Compare against the current pool so redundant updates don't cause node churn
+		current, err := client.GetSpotNodePool(cmd.Context(), org, name)
+		if err != nil {
+			if rxtspot.IsNotFound(err) {
+				return fmt.Errorf("spot node pool '%s' not found", name)
+			}
+			return fmt.Errorf("failed to get current spot node pool: %w", err)
+		}
+		effectiveDesired := current.Desired
+		if desiredStr != "" {
+			effectiveDesired = desired
+		}
+		minNodes, maxNodes, autoscale, err := autoscalingFlags(cmd, effectiveDesired, true)
+		if err != nil {
+			return err
+		}
+		changes := []fieldChange{}
+		if autoscale && (!current.Autoscaling.Enabled || current.Autoscaling.MinNodes != int64(minNodes) || current.Autoscaling.MaxNodes != int64(maxNodes)) {
+			changes = append(changes, fieldChange{
+				Field: "autoscaling",
+				From:  autoscalingRange(current.Autoscaling.Enabled, current.Autoscaling.MinNodes, current.Autoscaling.MaxNodes),
+				To:    fmt.Sprintf("%d-%d", minNodes, maxNodes),
+			})
+		}
+		if desiredStr != "" && desired != current.Desired {
+			changes = append(changes, fieldChange{Field: "desired", From: strconv.Itoa(current.Desired), To: strconv.Itoa(desired)})
+		}
+		if bidPrice != "" && bidValue(bidPrice) != bidValue(current.BidPrice) {
+			changes = append(changes, fieldChange{Field: "bidprice", From: current.BidPrice, To: bidPrice})
+		}
+		if customLabelsStr != "" && !maps.Equal(customLabels, current.CustomLabels) {
+			changes = append(changes, fieldChange{Field: "custom-labels", From: formatKeyValues(current.CustomLabels), To: formatKeyValues(customLabels)})
+		}
+		if customAnnotationsStr != "" && !maps.Equal(customAnnotations, current.CustomAnnotations) {
+			changes = append(changes, fieldChange{Field: "custom-annotations", From: formatKeyValues(current.CustomAnnotations), To: formatKeyValues(customAnnotations)})
+		}
+		if len(changes) == 0 && !force {
+			fmt.Fprintf(os.Stderr, "spot nodepool - %s: no changes (use --force to update anyway)\n", name)
+			return nil
+		}
+		if len(changes) > 0 {
+			descriptions := make([]string, len(changes))
+			for i, c := range changes {
+				descriptions[i] = c.String()
+			}
+			fmt.Fprintf(os.Stderr, "spot nodepool - %s changes: %s\n", name, strings.Join(descriptions, ", "))
+		}
+
 		pool := &rxtspot.SpotNodePool{
 			Name:              name,
 			Org:               org,
@@ -434,15 +888,44 @@ var spotUpdateCmd = &cobra.Command{
 			CustomLabels:      customLabels,
 			CustomAnnotations: customAnnotations,
 		}
+		if autoscale {
+			pool.Autoscaling.Enabled = true
+			pool.Autoscaling.MinNodes = int64(minNodes)
+			pool.Autoscaling.MaxNodes = int64(maxNodes)
+		} else {
+			// The SDK always sends autoscaling, so keep the current settings
+			pool.Autoscaling = current.Autoscaling
+		}
 
-		err = client.GetAPI().UpdateSpotNodePool(context.Background(), org, *pool)
+		err = retryOnConflict(cmd.Context(), "spot node pool "+name, retries, func(attempt int) error {
+			if resourceVersion == "" {
+				return client.UpdateSpotNodePool(cmd.Context(), org, *pool)
+			}
+			if attempt > 0 {
+				// Re-apply the same change on top of the version that won the race
+				rv, err := client.NodePoolResourceVersion(cmd.Context(), org, name, true)
+				if err != nil {
+					return fmt.Errorf("failed to get current spot node pool: %w", err)
+				}
+				resourceVersion = rv
+			}
+			return client.UpdateSpotNodePoolIfUnchanged(cmd.Context(), org, *pool, resourceVersion)
+		})
+		if rxtspot.IsConflict(err) && !force {
+			return fmt.Errorf("spot node pool '%s' changed since it was read; re-run the command to apply your changes to the new version (or use --retry-on-conflict to retry automatically, or --force to overwrite)", name)
+		}
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
+		if desiredStr != "" && desired == 0 {
+			if err := patchDesiredZero(cmd.Context(), client, org, name, true); err != nil {
+				return err
+			}
+		}
 
-		fmt.Printf("spot nodepool - %s updated successfully \n", pool.Name)
+		fmt.Fprintf(os.Stderr, "spot nodepool - %s updated successfully \n", pool.Name)
 
-		return internal.OutputData(pool, outputFormat)
+		return internal.OutputData(spotUpdateResult{SpotNodePool: *pool, Changes: changes}, outputFormat)
 	},
 }
 
@@ -456,31 +939,71 @@ var ondemandListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
-		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 		cloudspace, _ := cmd.Flags().GetString("cloudspace")
 
 		if org == "" || cloudspace == "" {
 			return fmt.Errorf("org and cloudspace are required")
 		}
+		selectorStr, _ := cmd.Flags().GetString("label-selector")
+		selector, err := parseLabelSelector(selectorStr)
+		if err != nil {
+			return err
+		}
 
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
 
-		pools, err := client.GetAPI().ListOnDemandNodePools(context.Background(), org, cloudspace)
+		pools, err := client.ListOnDemandNodePools(context.Background(), org, cloudspace)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
+		if len(selector) > 0 {
+			filtered := pools[:0]
+			for _, p := range pools {
+				if selector.matches(p.CustomLabels) {
+					filtered = append(filtered, p)
+				}
+			}
+			pools = filtered
+		}
 
+		if strings.EqualFold(outputFormat, "table") {
+			showTotals, _ := cmd.Flags().GetBool("totals")
+			var prices map[string]internal.ServerClassPrice
+			if showTotals && len(pools) > 0 {
+				prices, err = cloudspacePricing(cmd.Context(), client, org, cloudspace)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: on-demand prices unavailable: %v\n", err)
+				}
+			}
+
+			var totals poolTotals
+			rows := []onDemandPoolRow{}
+			for _, p := range pools {
+				totals.add(p.Desired, p.WonCount, prices[p.ServerClass].OnDemand)
+				rows = append(rows, onDemandPoolRow{
+					Name:        p.Name,
+					ServerClass: p.ServerClass,
+					Desired:     p.Desired,
+					Autoscaling: autoscalingRange(p.Autoscaling.Enabled, int64(p.Autoscaling.MinNodes), int64(p.Autoscaling.MaxNodes)),
+					Ready:       strconv.Itoa(p.WonCount),
+					Status:      p.Status,
+				})
+			}
+			if showTotals {
+				return internal.OutputDataWithTotals(rows, internal.TableTotals{
+					Row:     onDemandPoolRow{Name: totals.label(), Desired: totals.desired, Ready: strconv.Itoa(totals.ready)},
+					Summary: totals.summary(),
+				}, outputFormat)
+			}
+			return internal.OutputData(rows, outputFormat)
+		}
 		return internal.OutputData(pools, outputFormat)
 	},
 }
@@ -497,14 +1020,9 @@ var ondemandCreateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
-		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 		cloudspace, _ := cmd.Flags().GetString("cloudspace")
 		serverClass, _ := cmd.Flags().GetString("serverclass")
@@ -513,10 +1031,19 @@ var ondemandCreateCmd = &cobra.Command{
 		if name == "" || cloudspace == "" || serverClass == "" || desiredStr == "" {
 			return fmt.Errorf("name, org, cloudspace, serverclass, and desired are required")
 		}
+		porcelain, err := porcelainVersion(cmd)
+		if err != nil {
+			return err
+		}
 
-		desired, err := strconv.Atoi(desiredStr)
+		desired, err := parseDesired(desiredStr, false)
 		if err != nil {
-			return fmt.Errorf("desired must be a valid integer: %w", err)
+			return err
+		}
+
+		minNodes, maxNodes, autoscale, err := autoscalingFlags(cmd, desired, true)
+		if err != nil {
+			return err
 		}
 
 		customLabelsStr, _ := cmd.Flags().GetString("custom-labels")
@@ -537,6 +1064,31 @@ var ondemandCreateCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
+		if err := validateServerClass(context.Background(), client, org, cloudspace, serverClass); err != nil {
+			return err
+		}
+
+		priceCheck, _ := cmd.Flags().GetBool("price-check")
+		maxHourly, _ := cmd.Flags().GetFloat64("max-hourly")
+		if priceCheck || maxHourly > 0 {
+			hourly, err := onDemandPoolHourlyCost(cmd.Context(), client, org, cloudspace, serverClass, desired)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Projected cost for %d x %s: $%.3f/hour, about $%.2f/month\n", desired, serverClass, hourly, hourly*hoursPerMonth)
+			// Without a threshold every create is confirmed; with one, only pools above it
+			if maxHourly <= 0 || hourly > maxHourly {
+				yes, _ := cmd.Flags().GetBool("yes")
+				if !yes {
+					if !canPrompt() {
+						return fmt.Errorf("projected cost of $%.3f/hour requires confirmation (use --yes to create anyway)", hourly)
+					}
+					if !confirmYesNo("Create on-demand nodepool at $%.3f/hour? (y/N): ", hourly) {
+						return nil
+					}
+				}
+			}
+		}
 
 		pool := &rxtspot.OnDemandNodePool{
 			Name:              name,
@@ -547,6 +1099,11 @@ var ondemandCreateCmd = &cobra.Command{
 			CustomLabels:      customLabels,
 			CustomAnnotations: customAnnotations,
 		}
+		if autoscale {
+			pool.Autoscaling.Enabled = true
+			pool.Autoscaling.MinNodes = minNodes
+			pool.Autoscaling.MaxNodes = maxNodes
+		}
 
 		err = client.GetAPI().CreateOnDemandNodePool(context.Background(), org, *pool)
 		if err != nil {
@@ -558,33 +1115,61 @@ var ondemandCreateCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		fmt.Printf("on-demand nodepool - %s created successfully \n", pool.Name)
+		if porcelain != "" {
+			return writePorcelainPool(os.Stdout, pool.Name, pool.Status, pool.ServerClass, pool.Desired, "")
+		}
+		fmt.Fprintf(os.Stderr, "on-demand nodepool - %s created successfully \n", pool.Name)
 
 		return internal.OutputData(pool, outputFormat)
 	},
 }
 
+// cloudspacePricing returns the server class prices of the region of a cloudspace
+func cloudspacePricing(ctx context.Context, client *internal.Client, org, cloudspace string) (map[string]internal.ServerClassPrice, error) {
+	cs, err := client.GetCloudspace(ctx, org, cloudspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cloudspace %s: %w", cloudspace, err)
+	}
+	return client.RegionPricing(ctx, cs.Region)
+}
+
+// hoursPerMonth is the average number of hours in a month, used for cost projections
+const hoursPerMonth = 730
+
+// onDemandPoolHourlyCost returns the hourly cost of desired nodes of serverClass in the
+// region of the cloudspace
+func onDemandPoolHourlyCost(ctx context.Context, client *internal.Client, org, cloudspace, serverClass string, desired int) (float64, error) {
+	cs, err := client.GetCloudspace(ctx, org, cloudspace)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cloudspace %s: %w", cloudspace, err)
+	}
+	prices, err := client.RegionPricing(ctx, cs.Region)
+	if err != nil {
+		return 0, err
+	}
+	price, ok := prices[serverClass]
+	if !ok || price.OnDemand <= 0 {
+		return 0, fmt.Errorf("could not find on-demand price for server class %s in region %s", serverClass, cs.Region)
+	}
+	return price.OnDemand * float64(desired), nil
+}
+
 var ondemandGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get on-demand node pool",
 	Long:  `Get a on-demand node pool in a org.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name, _ := cmd.Flags().GetString("name")
-		if name == "" {
-			return fmt.Errorf("name is required")
-		}
 		cfg, err := config.GetCLIEssentials(cmd)
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		name, err := resolvePoolName(context.Background(), cmd, cfg, org, false)
+		if err != nil {
+			return err
 		}
 
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
@@ -592,7 +1177,7 @@ var ondemandGetCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		pool, err := client.GetAPI().GetOnDemandNodePool(context.Background(), org, name)
+		pool, err := client.GetOnDemandNodePool(context.Background(), org, name)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
@@ -612,14 +1197,9 @@ var ondemandUpdateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
-		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
 		}
 		cloudspace, _ := cmd.Flags().GetString("cloudspace")
 		desiredStr, _ := cmd.Flags().GetString("desired")
@@ -630,11 +1210,14 @@ var ondemandUpdateCmd = &cobra.Command{
 
 		var desired int
 		if desiredStr != "" {
-			desired, err = strconv.Atoi(desiredStr)
-			if err != nil {
-				return fmt.Errorf("desired must be a valid integer: %w", err)
+			if desired, err = parseDesired(desiredStr, true); err != nil {
+				return err
 			}
 		}
+		minNodes, maxNodes, autoscale, err := autoscalingFlags(cmd, desired, desiredStr != "")
+		if err != nil {
+			return err
+		}
 
 		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
 		if err != nil {
@@ -647,13 +1230,39 @@ var ondemandUpdateCmd = &cobra.Command{
 			Cloudspace: cloudspace,
 			Desired:    desired,
 		}
+		if autoscale {
+			pool.Autoscaling.Enabled = true
+			pool.Autoscaling.MinNodes = minNodes
+			pool.Autoscaling.MaxNodes = maxNodes
+		} else {
+			// The SDK always sends autoscaling, so keep the current settings
+			current, err := client.GetOnDemandNodePool(cmd.Context(), org, name)
+			if err != nil {
+				if rxtspot.IsNotFound(err) {
+					return fmt.Errorf("on-demand node pool '%s' not found", name)
+				}
+				return fmt.Errorf("failed to get current on-demand node pool: %w", err)
+			}
+			pool.Autoscaling = current.Autoscaling
+		}
 
-		err = client.GetAPI().UpdateOnDemandNodePool(context.Background(), org, *pool)
+		retries, err := conflictRetries(cmd)
+		if err != nil {
+			return err
+		}
+		err = retryOnConflict(cmd.Context(), "on-demand node pool "+name, retries, func(int) error {
+			return client.UpdateOnDemandNodePool(cmd.Context(), org, *pool)
+		})
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
+		if desiredStr != "" && desired == 0 {
+			if err := patchDesiredZero(cmd.Context(), client, org, name, false); err != nil {
+				return err
+			}
+		}
 
-		fmt.Printf("on-demand nodepool - %s updated successfully \n", pool.Name)
+		fmt.Fprintf(os.Stderr, "on-demand nodepool - %s updated successfully \n", pool.Name)
 
 		return internal.OutputData(pool, outputFormat)
 	},
@@ -665,34 +1274,41 @@ var ondemandDeleteCmd = &cobra.Command{
 	Short: "Delete ondemand node pools",
 	Long:  `Delete ondemand node pools in a org.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name, _ := cmd.Flags().GetString("name")
-		if name == "" {
-			return fmt.Errorf("name is required")
-		}
 
 		cfg, err := config.GetCLIEssentials(cmd)
 		if err != nil {
 			return err
 		}
-		org, _ := cmd.Flags().GetString("org")
-		if org == "" {
-			if err == nil && cfg.Org != "" {
-				org = cfg.Org
-			}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			return deleteAllPools(cmd, cfg, org, false)
+		}
+		name, err := resolvePoolName(context.Background(), cmd, cfg, org, false)
+		if err != nil {
+			return err
 		}
-		if org == "" {
-			return fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+			pool, err := client.GetOnDemandNodePool(context.Background(), org, name)
+			if err != nil {
+				if rxtspot.IsNotFound(err) {
+					return fmt.Errorf("ondemand node pool '%s' not found", name)
+				}
+				return fmt.Errorf("%w", err)
+			}
+			return reportDryRunDeleted(cmd, "ondemandnodepool", name, fmt.Sprintf("ondemand node pool - %s (cloudspace %s) would be deleted (dry run)", name, pool.Cloudspace))
 		}
 		yes, _ := cmd.Flags().GetBool("yes")
 		if !yes {
 			// Interactive prompt
-			prompt := color.New(color.FgYellow).PrintfFunc()
-			prompt("Are you sure you want to delete ondemand nodepool '%s'? (y/N): ", name)
-
-			var response string
-			_, err := fmt.Scanln(&response)
-			if err != nil || (response != "y" && response != "Y") {
-				fmt.Println("Aborted.")
+			if !confirmYesNo("Are you sure you want to delete ondemand nodepool '%s'? (y/N): ", name) {
 				return nil
 			}
 		}
@@ -705,8 +1321,316 @@ var ondemandDeleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
-		fmt.Printf("ondemand node pool - %s deleted successfully \n", name)
+		if wait, _ := cmd.Flags().GetBool("wait-for-delete"); wait {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			err := waitForDeletion(cmd.Context(), fmt.Sprintf("ondemand node pool '%s'", name), timeout, func(ctx context.Context) error {
+				_, err := client.GetOnDemandNodePool(ctx, org, name)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return reportDeleted(cmd, "ondemandnodepool", name, fmt.Sprintf("ondemand node pool - %s deleted successfully", name))
+	},
+}
+
+// deleteAllPools deletes every spot or on-demand node pool in --cloudspace in concurrent batches
+func deleteAllPools(cmd *cobra.Command, cfg *config.SpotConfig, org string, spot bool) error {
+	cloudspace, _ := cmd.Flags().GetString("cloudspace")
+	if cloudspace == "" {
+		return fmt.Errorf("--all requires --cloudspace")
+	}
+	if cmd.Flags().Changed("name") || cmd.Flags().Changed("pool-name") {
+		return fmt.Errorf("--all cannot be combined with --name or --pool-name")
+	}
+	client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
 
+	ctx := cmd.Context()
+	what, kind := "ondemand node pool", "ondemandnodepool"
+	var names []string
+	del := func(ctx context.Context, name string) error {
+		return client.GetAPI().DeleteOnDemandNodePool(ctx, org, name)
+	}
+	if spot {
+		what, kind = "spot node pool", "spotnodepool"
+		del = func(ctx context.Context, name string) error {
+			return client.GetAPI().DeleteSpotNodePool(ctx, org, name)
+		}
+		pools, err := client.GetAPI().ListSpotNodePools(ctx, org, cloudspace)
+		if err != nil {
+			return fmt.Errorf("failed to list spot node pools: %w", err)
+		}
+		for _, p := range pools {
+			names = append(names, p.Name)
+		}
+	} else {
+		pools, err := client.GetAPI().ListOnDemandNodePools(ctx, org, cloudspace)
+		if err != nil {
+			return fmt.Errorf("failed to list on-demand node pools: %w", err)
+		}
+		for _, p := range pools {
+			names = append(names, p.Name)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(os.Stderr, "No %ss found in cloudspace %s\n", what, cloudspace)
+		return nil
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		return reportBatchDryRunDeleted(cmd, kind, names, func(name string) string {
+			return fmt.Sprintf("%s - %s (cloudspace %s) would be deleted (dry run)", what, name, cloudspace)
+		})
+	}
+	if !confirmBatchDelete(cmd, what, names) {
 		return nil
+	}
+
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	summary := deleteInBatches(ctx, os.Stderr, what, names, parallelism, del)
+	return reportBatchDeleted(cmd, kind, what, summary)
+}
+
+// nodePoolRow is a single row of the unified node pool listing, tagged with its location
+type nodePoolRow struct {
+	Org         string `json:"org" yaml:"org"`
+	Cloudspace  string `json:"cloudspace" yaml:"cloudspace"`
+	Type        string `json:"type" yaml:"type"`
+	Name        string `json:"name" yaml:"name"`
+	ServerClass string `json:"serverclass" yaml:"serverclass"`
+	Desired     int    `json:"desired" yaml:"desired"`
+	BidPrice    string `json:"bidprice,omitempty" yaml:"bidprice,omitempty"`
+}
+
+// nodePoolTarget identifies a cloudspace whose node pools should be listed
+type nodePoolTarget struct {
+	org        string
+	cloudspace string
+}
+
+// nodepoolsListCmd represents the unified nodepools list command
+var nodepoolsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List spot and on-demand node pools",
+	Long: `List spot and on-demand node pools across one cloudspace, every cloudspace in an organization,
+or every accessible organization (--all-orgs). Failures for individual organizations or cloudspaces
+are reported without aborting the rest of the listing. --only-spot and --only-ondemand limit the
+listing to one pool type and skip the API calls for the other.
+
+For very large fleets --stream fetches the pools in pages and writes each page as soon as it
+arrives, as newline-delimited JSON with -o json or as table rows with -o table. Streamed pools
+are written in arrival order rather than sorted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		allOrgs, _ := cmd.Flags().GetBool("all-orgs")
+		cloudspace, _ := cmd.Flags().GetString("cloudspace")
+		parallelism, _ := cmd.Flags().GetInt("parallelism")
+		if parallelism < 1 {
+			return fmt.Errorf("parallelism must be at least 1")
+		}
+		if allOrgs && cloudspace != "" {
+			return fmt.Errorf("--cloudspace cannot be combined with --all-orgs")
+		}
+		onlySpot, _ := cmd.Flags().GetBool("only-spot")
+		onlyOnDemand, _ := cmd.Flags().GetBool("only-ondemand")
+		if onlySpot && onlyOnDemand {
+			return fmt.Errorf("--only-spot cannot be combined with --only-ondemand")
+		}
+		selectorStr, _ := cmd.Flags().GetString("label-selector")
+		selector, err := parseLabelSelector(selectorStr)
+		if err != nil {
+			return err
+		}
+		var stream *internal.RowStream
+		if streamed, _ := cmd.Flags().GetBool("stream"); streamed {
+			if stream, err = internal.NewRowStream(outputFormat); err != nil {
+				return err
+			}
+		}
+
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		ctx := cmd.Context()
+
+		var orgs []string
+		if allOrgs {
+			organizations, err := client.GetAPI().ListOrganizations(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list organizations: %w", err)
+			}
+			for _, organization := range organizations {
+				orgs = append(orgs, organization.Name)
+			}
+		} else {
+			org, err := resolveOrg(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			orgs = []string{org}
+		}
+
+		var (
+			mu       sync.Mutex
+			failures []string
+			targets  []nodePoolTarget
+		)
+
+		// Resolve the cloudspaces to walk
+		if cloudspace != "" {
+			targets = append(targets, nodePoolTarget{org: orgs[0], cloudspace: cloudspace})
+		} else {
+			forEachLimit(len(orgs), parallelism, func(i int) {
+				cloudspaces, err := client.GetAPI().ListCloudspaces(ctx, orgs[i])
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					failures = append(failures, fmt.Sprintf("org %s: %v", orgs[i], err))
+					return
+				}
+				for _, cs := range cloudspaces.Items {
+					targets = append(targets, nodePoolTarget{org: orgs[i], cloudspace: cs.Name})
+				}
+			})
+		}
+
+		// List the node pools of every cloudspace
+		rows := []nodePoolRow{}
+		forEachLimit(len(targets), parallelism, func(i int) {
+			t := targets[i]
+			if stream != nil {
+				var spotErr, onDemandErr error
+				if !onlyOnDemand {
+					spotErr = client.ListSpotNodePoolPages(ctx, t.org, t.cloudspace, func(pools []*rxtspot.SpotNodePool) error {
+						return stream.Write(spotNodePoolRows(t, pools, selector))
+					})
+				}
+				if !onlySpot {
+					onDemandErr = client.ListOnDemandNodePoolPages(ctx, t.org, t.cloudspace, func(pools []*rxtspot.OnDemandNodePool) error {
+						return stream.Write(onDemandNodePoolRows(t, pools, selector))
+					})
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if spotErr != nil {
+					failures = append(failures, fmt.Sprintf("org %s, cloudspace %s (spot): %v", t.org, t.cloudspace, spotErr))
+				}
+				if onDemandErr != nil {
+					failures = append(failures, fmt.Sprintf("org %s, cloudspace %s (on-demand): %v", t.org, t.cloudspace, onDemandErr))
+				}
+				return
+			}
+
+			var (
+				spotPools            []*rxtspot.SpotNodePool
+				onDemandPools        []*rxtspot.OnDemandNodePool
+				spotErr, onDemandErr error
+			)
+			if !onlyOnDemand {
+				spotPools, spotErr = client.GetAPI().ListSpotNodePools(ctx, t.org, t.cloudspace)
+			}
+			if !onlySpot {
+				onDemandPools, onDemandErr = client.GetAPI().ListOnDemandNodePools(ctx, t.org, t.cloudspace)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if spotErr != nil {
+				failures = append(failures, fmt.Sprintf("org %s, cloudspace %s (spot): %v", t.org, t.cloudspace, spotErr))
+			}
+			if onDemandErr != nil {
+				failures = append(failures, fmt.Sprintf("org %s, cloudspace %s (on-demand): %v", t.org, t.cloudspace, onDemandErr))
+			}
+			rows = append(rows, spotNodePoolRows(t, spotPools, selector)...)
+			rows = append(rows, onDemandNodePoolRows(t, onDemandPools, selector)...)
+		})
+
+		if stream != nil {
+			if err := stream.Close(); err != nil {
+				return err
+			}
+			return nodePoolListFailures(failures)
+		}
+
+		// Concurrent collection is unordered; sort for stable output
+		sort.Slice(rows, func(i, j int) bool {
+			a, b := rows[i], rows[j]
+			if a.Org != b.Org {
+				return a.Org < b.Org
+			}
+			if a.Cloudspace != b.Cloudspace {
+				return a.Cloudspace < b.Cloudspace
+			}
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			return a.Name < b.Name
+		})
+
+		if err := internal.OutputData(rows, outputFormat); err != nil {
+			return err
+		}
+		return nodePoolListFailures(failures)
 	},
 }
+
+// spotNodePoolRows converts the spot pools of a cloudspace that match selector into rows of
+// the unified listing
+func spotNodePoolRows(t nodePoolTarget, pools []*rxtspot.SpotNodePool, selector labelSelector) []nodePoolRow {
+	rows := []nodePoolRow{}
+	for _, p := range pools {
+		if !selector.matches(p.CustomLabels) {
+			continue
+		}
+		rows = append(rows, nodePoolRow{
+			Org:         t.org,
+			Cloudspace:  t.cloudspace,
+			Type:        "spot",
+			Name:        p.Name,
+			ServerClass: p.ServerClass,
+			Desired:     p.Desired,
+			BidPrice:    p.BidPrice,
+		})
+	}
+	return rows
+}
+
+// onDemandNodePoolRows is spotNodePoolRows for on-demand pools
+func onDemandNodePoolRows(t nodePoolTarget, pools []*rxtspot.OnDemandNodePool, selector labelSelector) []nodePoolRow {
+	rows := []nodePoolRow{}
+	for _, p := range pools {
+		if !selector.matches(p.CustomLabels) {
+			continue
+		}
+		rows = append(rows, nodePoolRow{
+			Org:         t.org,
+			Cloudspace:  t.cloudspace,
+			Type:        "ondemand",
+			Name:        p.Name,
+			ServerClass: p.ServerClass,
+			Desired:     p.Desired,
+		})
+	}
+	return rows
+}
+
+// nodePoolListFailures reports the requests of a node pool listing that failed, returning an
+// error when there were any
+func nodePoolListFailures(failures []string) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	sort.Strings(failures)
+	for _, f := range failures {
+		fmt.Fprintf(os.Stderr, "Warning: failed to list node pools for %s\n", f)
+	}
+	return fmt.Errorf("node pool listing incomplete: %d request(s) failed", len(failures))
+}
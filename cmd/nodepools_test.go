@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseDesired(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		allowZero bool
+		want      int
+		wantErr   string
+	}{
+		{name: "positive", value: "3", want: 3},
+		{name: "surrounding spaces", value: " 2 ", want: 2},
+		{name: "empty", value: "", wantErr: "desired is required"},
+		{name: "blank", value: "  ", wantErr: "desired is required"},
+		{name: "zero on create", value: "0", wantErr: "desired must be at least 1"},
+		{name: "zero on update", value: "0", allowZero: true, want: 0},
+		{name: "negative", value: "-1", wantErr: "desired must not be negative, got -1"},
+		{name: "negative on update", value: "-1", allowZero: true, wantErr: "desired must not be negative, got -1"},
+		{name: "non-numeric", value: "three", wantErr: `desired must be a whole number, got "three"`},
+		{name: "fraction", value: "1.5", wantErr: `desired must be a whole number, got "1.5"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDesired(tt.value, tt.allowZero)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("parseDesired(%q, %v) error = %v, want %q", tt.value, tt.allowZero, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDesired(%q, %v) unexpected error: %v", tt.value, tt.allowZero, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseDesired(%q, %v) = %d, want %d", tt.value, tt.allowZero, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpotUpdateKeepsAutoscaling(t *testing.T) {
+	api := newFakeAPI(t)
+	path := "/apis/ngpc.rxt.io/v1/namespaces/" + fakeOrgNamespace + "/spotnodepools/pool-a"
+	api.set(path, map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "pool-a", "resourceVersion": "7"},
+		"spec": map[string]interface{}{
+			"cloudSpace":  "prod",
+			"serverClass": "gp.vs1.medium-dfw",
+			"desired":     2,
+			"bidPrice":    "0.08",
+			"autoscaling": map[string]interface{}{"enabled": true, "minNodes": 1, "maxNodes": 5},
+		},
+	})
+
+	if err := runCommand(t, "nodepools", "spot", "update", "--name", "pool-a", "--cloudspace", "prod", "--desired", "3"); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	patches := api.received("PATCH", path)
+	if len(patches) != 1 {
+		t.Fatalf("got %d PATCH requests, want 1", len(patches))
+	}
+	spec, _ := patches[0].Body["spec"].(map[string]interface{})
+	if spec["desired"] != float64(3) {
+		t.Errorf("desired = %v, want 3", spec["desired"])
+	}
+	want := map[string]interface{}{"enabled": true, "minNodes": float64(1), "maxNodes": float64(5)}
+	if got := spec["autoscaling"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("autoscaling = %v, want %v", got, want)
+	}
+}
+
+func TestOnDemandUpdateRetriesOnConflict(t *testing.T) {
+	api := newFakeAPI(t)
+	path := "/apis/ngpc.rxt.io/v1/namespaces/" + fakeOrgNamespace + "/ondemandnodepools/pool-b"
+	api.set(path, map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "pool-b"},
+		"spec":     map[string]interface{}{"cloudSpace": "prod", "serverClass": "gp.vs1.medium-dfw", "desired": 1},
+	})
+	api.fail("PATCH", path, http.StatusConflict)
+
+	if err := runCommand(t, "nodepools", "ondemand", "update", "--name", "pool-b", "--cloudspace", "prod", "--desired", "2", "--retry-on-conflict=1"); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if got := len(api.received("PATCH", path)); got != 2 {
+		t.Errorf("got %d PATCH requests, want 2", got)
+	}
+}
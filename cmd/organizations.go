@@ -3,6 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/rackspace-spot/spotctl/internal"
 	config "github.com/rackspace-spot/spotctl/pkg"
@@ -67,7 +70,18 @@ var organizationsGetCmd = &cobra.Command{
 		// Find the organization with the matching org
 		for _, organization := range orgs {
 			if organization.Name == orgName {
-				return internal.OutputData(organization, outputFormat)
+				includeUsage, _ := cmd.Flags().GetBool("include-usage")
+				if !includeUsage {
+					return internal.OutputData(organization, outputFormat)
+				}
+				usage, err := getOrganizationUsage(cmd.Context(), client, orgName)
+				if err != nil {
+					return fmt.Errorf("failed to compute usage for organization '%s': %w", orgName, err)
+				}
+				return internal.OutputData(organizationWithUsage{
+					Organization: organization,
+					Usage:        usage,
+				}, outputFormat)
 			}
 		}
 
@@ -75,11 +89,91 @@ var organizationsGetCmd = &cobra.Command{
 	},
 }
 
+// validateOrgAccess checks that org names, or is the ID of, an organization the user can access
+func validateOrgAccess(ctx context.Context, client *internal.Client, org string) error {
+	orgs, err := client.ListOrganizations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list organizations: %w", err)
+	}
+	var names []string
+	for _, o := range orgs {
+		if o.Name == org || o.ID == org {
+			return nil
+		}
+		names = append(names, o.Name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return fmt.Errorf("org '%s' not found or not accessible; no organizations are accessible with these credentials", org)
+	}
+	return fmt.Errorf("org '%s' not found or not accessible; accessible orgs: %s", org, strings.Join(names, ", "))
+}
+
+// organizationUsage summarizes the resources running in an organization
+type organizationUsage struct {
+	Cloudspaces       int `json:"cloudspaces" yaml:"cloudspaces"`
+	SpotNodePools     int `json:"spotNodePools" yaml:"spotNodePools"`
+	OnDemandNodePools int `json:"onDemandNodePools" yaml:"onDemandNodePools"`
+	DesiredNodes      int `json:"desiredNodes" yaml:"desiredNodes"`
+}
+
+// organizationWithUsage is the output of 'organizations get --include-usage'
+type organizationWithUsage struct {
+	Organization interface{}       `json:"organization" yaml:"organization"`
+	Usage        organizationUsage `json:"usage" yaml:"usage"`
+}
+
+// getOrganizationUsage counts the cloudspaces and node pools of an organization,
+// listing the node pools of each cloudspace concurrently
+func getOrganizationUsage(ctx context.Context, client *internal.Client, org string) (organizationUsage, error) {
+	var usage organizationUsage
+
+	cloudspaces, err := client.GetAPI().ListCloudspaces(ctx, org)
+	if err != nil {
+		return usage, fmt.Errorf("failed to list cloudspaces: %w", err)
+	}
+	var names []string
+	for _, cs := range cloudspaces.Items {
+		names = append(names, cs.Name)
+	}
+	usage.Cloudspaces = len(names)
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+	forEachLimit(len(names), defaultParallelism, func(i int) {
+		spotPools, spotErr := client.GetAPI().ListSpotNodePools(ctx, org, names[i])
+		onDemandPools, onDemandErr := client.GetAPI().ListOnDemandNodePools(ctx, org, names[i])
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, err := range []error{spotErr, onDemandErr} {
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("failed to list node pools for cloudspace %s: %w", names[i], err)
+			}
+		}
+		usage.SpotNodePools += len(spotPools)
+		usage.OnDemandNodePools += len(onDemandPools)
+		for _, p := range spotPools {
+			usage.DesiredNodes += p.Desired
+		}
+		for _, p := range onDemandPools {
+			usage.DesiredNodes += p.Desired
+		}
+	})
+	if firstErr != nil {
+		return usage, firstErr
+	}
+	return usage, nil
+}
+
 func init() {
 	rootCmd.AddCommand(organizationsCmd)
 	organizationsCmd.AddCommand(organizationsListCmd)
 	organizationsCmd.AddCommand(organizationsGetCmd)
 	organizationsGetCmd.Flags().String("name", "", "Organization name (required)")
+	organizationsGetCmd.Flags().Bool("include-usage", false, "Include counts of cloudspaces and node pools in the organization")
 
 	organizationsGetCmd.MarkFlagRequired("name")
 }
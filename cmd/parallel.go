@@ -0,0 +1,26 @@
+package cmd
+
+import "sync"
+
+// defaultParallelism is the default number of concurrent API requests for fan-out operations
+const defaultParallelism = 4
+
+// forEachLimit calls fn for every index in [0, n) using at most limit concurrent goroutines
+// and waits for all calls to finish. A limit below 1 runs the calls sequentially.
+func forEachLimit(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+// Annotations recording a paused pool's scale so that resume can restore it
+const (
+	pausedDesiredAnnotation     = "spotctl.rackspace.com/paused-desired"
+	pausedAutoscalingAnnotation = "spotctl.rackspace.com/paused-autoscaling"
+)
+
+// pausablePool is the scale of one node pool of a paused or running cloudspace
+type pausablePool struct {
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type" yaml:"type"`
+	Desired     int    `json:"desired" yaml:"desired"`
+	Autoscaling string `json:"autoscaling,omitempty" yaml:"autoscaling,omitempty"`
+
+	spot        bool
+	annotations map[string]string
+}
+
+// formatAutoscaling formats an enabled autoscaling range as "min-max"
+func formatAutoscaling(enabled bool, minNodes, maxNodes int64) string {
+	if !enabled {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", minNodes, maxNodes)
+}
+
+// listPausablePools returns the spot and on-demand pools of a cloudspace
+func listPausablePools(ctx context.Context, client *internal.Client, org, cloudspace string) ([]pausablePool, error) {
+	spotPools, err := client.ListSpotNodePools(ctx, org, cloudspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spot node pools: %w", err)
+	}
+	onDemandPools, err := client.ListOnDemandNodePools(ctx, org, cloudspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list on-demand node pools: %w", err)
+	}
+	var pools []pausablePool
+	for _, p := range spotPools {
+		pools = append(pools, pausablePool{
+			Name: p.Name, Type: "spot", Desired: p.Desired, spot: true, annotations: p.CustomAnnotations,
+			Autoscaling: formatAutoscaling(p.Autoscaling.Enabled, p.Autoscaling.MinNodes, p.Autoscaling.MaxNodes),
+		})
+	}
+	for _, p := range onDemandPools {
+		pools = append(pools, pausablePool{
+			Name: p.Name, Type: "ondemand", Desired: p.Desired, annotations: p.CustomAnnotations,
+			Autoscaling: formatAutoscaling(p.Autoscaling.Enabled, int64(p.Autoscaling.MinNodes), int64(p.Autoscaling.MaxNodes)),
+		})
+	}
+	return pools, nil
+}
+
+// pauseTarget resolves the org and cloudspace of pause and resume and creates a client
+func pauseTarget(cmd *cobra.Command) (*internal.Client, string, string, error) {
+	name, _ := cmd.Flags().GetString("name")
+	if name == "" {
+		return nil, "", "", fmt.Errorf("name is required")
+	}
+	cfg, err := config.GetCLIEssentials(cmd)
+	if err != nil {
+		return nil, "", "", err
+	}
+	org, err := resolveOrg(cmd, cfg)
+	if err != nil {
+		return nil, "", "", err
+	}
+	client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("%w", err)
+	}
+	return client, org, name, nil
+}
+
+// cloudspacesPauseCmd represents the cloudspaces pause command
+var cloudspacesPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Scale every node pool of a cloudspace to zero",
+	Long: `Scale every spot and on-demand node pool of a cloudspace to zero nodes. Each pool's
+desired count and autoscaling range are saved in its custom annotations so that
+'cloudspaces resume' can restore them. Pools that are already paused are left alone.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, org, name, err := pauseTarget(cmd)
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		pools, err := listPausablePools(ctx, client, org, name)
+		if err != nil {
+			return err
+		}
+		var targets []pausablePool
+		for _, p := range pools {
+			if _, paused := p.annotations[pausedDesiredAnnotation]; !paused {
+				targets = append(targets, p)
+			}
+		}
+		if len(targets) == 0 {
+			fmt.Fprintf(os.Stderr, "Cloudspace '%s' has no running node pools to pause\n", name)
+			return internal.OutputData([]pausablePool{}, outputFormat)
+		}
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			fmt.Fprintf(os.Stderr, "%d node pool(s) would be scaled to zero (dry run)\n", len(targets))
+			return internal.OutputData(targets, outputFormat)
+		}
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			if !confirmYesNo("Scale %d node pool(s) of cloudspace '%s' to zero? (y/N): ", len(targets), name) {
+				return nil
+			}
+		}
+
+		for _, p := range targets {
+			annotations := map[string]interface{}{
+				pausedDesiredAnnotation: strconv.Itoa(p.Desired),
+			}
+			if p.Autoscaling != "" {
+				annotations[pausedAutoscalingAnnotation] = p.Autoscaling
+			}
+			spec := map[string]interface{}{
+				"desired":           0,
+				"autoscaling":       map[string]interface{}{"enabled": false},
+				"customAnnotations": annotations,
+			}
+			if err := client.PatchNodePoolSpec(ctx, org, p.Name, p.spot, spec); err != nil {
+				return fmt.Errorf("failed to pause %s node pool %s: %w", p.Type, p.Name, err)
+			}
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "Paused %s node pool %s (desired %d)\n", p.Type, p.Name, p.Desired)
+			}
+		}
+		client.InvalidateCloudspace(org, name)
+		return internal.OutputData(targets, outputFormat)
+	},
+}
+
+// cloudspacesResumeCmd represents the cloudspaces resume command
+var cloudspacesResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Restore the node pools of a paused cloudspace",
+	Long: `Restore the desired count and autoscaling range saved by 'cloudspaces pause' on every
+paused node pool of a cloudspace.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, org, name, err := pauseTarget(cmd)
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		pools, err := listPausablePools(ctx, client, org, name)
+		if err != nil {
+			return err
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		resumed := []pausablePool{}
+		for _, p := range pools {
+			saved, paused := p.annotations[pausedDesiredAnnotation]
+			if !paused {
+				continue
+			}
+			desired, err := strconv.Atoi(saved)
+			if err != nil {
+				return fmt.Errorf("%s node pool %s has an invalid %s annotation %q", p.Type, p.Name, pausedDesiredAnnotation, saved)
+			}
+			autoscaling := map[string]interface{}{"enabled": false}
+			if r := p.annotations[pausedAutoscalingAnnotation]; r != "" {
+				minStr, maxStr, _ := strings.Cut(r, "-")
+				minNodes, minErr := strconv.Atoi(minStr)
+				maxNodes, maxErr := strconv.Atoi(maxStr)
+				if minErr != nil || maxErr != nil {
+					return fmt.Errorf("%s node pool %s has an invalid %s annotation %q", p.Type, p.Name, pausedAutoscalingAnnotation, r)
+				}
+				autoscaling = map[string]interface{}{"enabled": true, "minNodes": minNodes, "maxNodes": maxNodes}
+			}
+			p.Desired = desired
+			p.Autoscaling = p.annotations[pausedAutoscalingAnnotation]
+			resumed = append(resumed, p)
+			if dryRun {
+				continue
+			}
+
+			spec := map[string]interface{}{
+				"desired":     desired,
+				"autoscaling": autoscaling,
+				"customAnnotations": map[string]interface{}{
+					pausedDesiredAnnotation:     nil,
+					pausedAutoscalingAnnotation: nil,
+				},
+			}
+			if err := client.PatchNodePoolSpec(ctx, org, p.Name, p.spot, spec); err != nil {
+				return fmt.Errorf("failed to resume %s node pool %s: %w", p.Type, p.Name, err)
+			}
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "Resumed %s node pool %s (desired %d)\n", p.Type, p.Name, desired)
+			}
+		}
+		if len(resumed) == 0 {
+			fmt.Fprintf(os.Stderr, "Cloudspace '%s' has no paused node pools\n", name)
+		} else if dryRun {
+			fmt.Fprintf(os.Stderr, "%d node pool(s) would be resumed (dry run)\n", len(resumed))
+		}
+		client.InvalidateCloudspace(org, name)
+		return internal.OutputData(resumed, outputFormat)
+	},
+}
+
+func init() {
+	cloudspacesCmd.AddCommand(cloudspacesPauseCmd)
+	cloudspacesCmd.AddCommand(cloudspacesResumeCmd)
+
+	for _, c := range []*cobra.Command{cloudspacesPauseCmd, cloudspacesResumeCmd} {
+		c.Flags().String("name", "", "Cloudspace name (required)")
+		c.Flags().String("org", "", "Organization ID")
+		c.Flags().Bool("dry-run", false, "Print the node pools that would change without changing them")
+		c.MarkFlagRequired("name")
+	}
+	cloudspacesPauseCmd.Flags().BoolP("yes", "y", false, "Automatic yes to prompts; assume \"yes\" as answer")
+}
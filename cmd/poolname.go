@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+// poolNameLabel is the custom label matched by --pool-name in addition to the pool UUID
+const poolNameLabel = "name"
+
+// poolRef is the part of a node pool used to resolve --pool-name
+type poolRef struct {
+	name   string
+	labels map[string]string
+}
+
+// resolvePoolName returns the UUID of the node pool selected by --name, or by --pool-name
+// within --cloudspace. A pool name matches a pool whose UUID or "name" custom label equals it,
+// or failing that, a unique UUID prefix.
+func resolvePoolName(ctx context.Context, cmd *cobra.Command, cfg *config.SpotConfig, org string, spot bool) (string, error) {
+	name, _ := cmd.Flags().GetString("name")
+	poolName, _ := cmd.Flags().GetString("pool-name")
+	if name != "" && poolName != "" {
+		return "", fmt.Errorf("--name and --pool-name cannot be combined")
+	}
+	if name != "" {
+		return name, nil
+	}
+	if poolName == "" {
+		return "", fmt.Errorf("name is required (use --name, or --cloudspace with --pool-name)")
+	}
+	cloudspace, _ := cmd.Flags().GetString("cloudspace")
+	if cloudspace == "" {
+		return "", fmt.Errorf("--pool-name requires --cloudspace")
+	}
+
+	client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+	var refs []poolRef
+	if spot {
+		pools, err := client.GetAPI().ListSpotNodePools(ctx, org, cloudspace)
+		if err != nil {
+			return "", fmt.Errorf("failed to list spot node pools: %w", err)
+		}
+		for _, p := range pools {
+			refs = append(refs, poolRef{name: p.Name, labels: p.CustomLabels})
+		}
+	} else {
+		pools, err := client.GetAPI().ListOnDemandNodePools(ctx, org, cloudspace)
+		if err != nil {
+			return "", fmt.Errorf("failed to list on-demand node pools: %w", err)
+		}
+		for _, p := range pools {
+			refs = append(refs, poolRef{name: p.Name, labels: p.CustomLabels})
+		}
+	}
+	return matchPoolName(refs, poolName, cloudspace)
+}
+
+// matchPoolName picks the single pool in refs identified by poolName
+func matchPoolName(refs []poolRef, poolName, cloudspace string) (string, error) {
+	var exact, prefix []string
+	for _, r := range refs {
+		switch {
+		case r.name == poolName || r.labels[poolNameLabel] == poolName:
+			exact = append(exact, r.name)
+		case strings.HasPrefix(r.name, poolName):
+			prefix = append(prefix, r.name)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = prefix
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no node pool named '%s' in cloudspace %s", poolName, cloudspace)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("pool name '%s' is ambiguous in cloudspace %s, matching: %s", poolName, cloudspace, strings.Join(matches, ", "))
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// porcelainV1 is the first (and current) porcelain format: one tab-separated line of
+//
+//	NAME  STATUS  SERVERCLASS  DESIRED  BID
+//
+// with "-" for empty fields. On-demand pools have no bid, so BID is always "-" for them.
+// Fields are only ever appended in a new version; existing columns never move.
+const porcelainV1 = "v1"
+
+// addPorcelainFlag adds --porcelain[=VERSION] to a create command
+func addPorcelainFlag(cmd *cobra.Command) {
+	cmd.Flags().String("porcelain", "", "Print a single stable tab-separated line (NAME STATUS SERVERCLASS DESIRED BID) instead of the created object; the only format is v1")
+	cmd.Flags().Lookup("porcelain").NoOptDefVal = porcelainV1
+}
+
+// porcelainVersion returns the requested porcelain format, or "" when --porcelain is not set
+func porcelainVersion(cmd *cobra.Command) (string, error) {
+	version, _ := cmd.Flags().GetString("porcelain")
+	switch version {
+	case "", porcelainV1:
+		return version, nil
+	default:
+		return "", fmt.Errorf("unsupported --porcelain format %q (supported: %s)", version, porcelainV1)
+	}
+}
+
+// writePorcelainPool writes the porcelain line of a node pool
+func writePorcelainPool(w io.Writer, name, status, serverClass string, desired int, bid string) error {
+	fields := []string{name, status, serverClass, strconv.Itoa(desired), bid}
+	for i, f := range fields {
+		f = strings.Join(strings.Fields(f), " ")
+		if f == "" {
+			f = "-"
+		}
+		fields[i] = f
+	}
+	_, err := fmt.Fprintln(w, strings.Join(fields, "\t"))
+	return err
+}
@@ -3,6 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/rackspace-spot/spotctl/internal"
 	config "github.com/rackspace-spot/spotctl/pkg"
@@ -39,8 +42,123 @@ var pricingGetCmd = &cobra.Command{
 	},
 }
 
+// priceComparison is a single row of the pricing comparison table
+type priceComparison struct {
+	ServerClass     string `json:"serverclass" yaml:"serverclass"`
+	CPU             string `json:"cpu" yaml:"cpu"`
+	Memory          string `json:"memory" yaml:"memory"`
+	MarketPrice     string `json:"marketPrice" yaml:"marketPrice"`
+	MinBidPrice     string `json:"minBidPrice" yaml:"minBidPrice"`
+	OnDemandPrice   string `json:"onDemandPrice" yaml:"onDemandPrice"`
+	CPUPerDollar    string `json:"cpuPerDollar,omitempty" yaml:"cpuPerDollar,omitempty"`
+	MemoryPerDollar string `json:"memoryPerDollar,omitempty" yaml:"memoryPerDollar,omitempty"`
+}
+
+var pricingCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare pricing across serverclasses",
+	Long:  `Compare market, minimum bid and on-demand prices for serverclasses in a region, sorted by market price.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		region := resolveRegion(cmd, cfg)
+		if !isValidRegion(region) {
+			return invalidRegionError(region)
+		}
+		classes, _ := cmd.Flags().GetStringSlice("serverclass")
+		all, _ := cmd.Flags().GetBool("all")
+		perDollar, _ := cmd.Flags().GetBool("per-dollar")
+		if len(classes) == 0 && !all {
+			return fmt.Errorf("specify serverclasses with --serverclass or use --all")
+		}
+		if len(classes) > 0 && all {
+			return fmt.Errorf("--serverclass and --all are mutually exclusive")
+		}
+
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		serverClassList, err := client.ListServerClasses(cmd.Context(), region)
+		if err != nil {
+			return fmt.Errorf("failed to list server classes for region %s: %w", region, err)
+		}
+
+		wanted := make(map[string]bool)
+		for _, c := range classes {
+			wanted[strings.TrimSpace(c)] = true
+		}
+		rows := []priceComparison{}
+		if serverClassList != nil {
+			for _, sc := range serverClassList.Items {
+				if !all && !wanted[sc.Name] {
+					continue
+				}
+				delete(wanted, sc.Name)
+				row := priceComparison{
+					ServerClass:   sc.Name,
+					CPU:           sc.Resources.CPU,
+					Memory:        sc.Resources.Memory,
+					MarketPrice:   sc.CurrentMarketPricePerHour,
+					MinBidPrice:   sc.MinBidPricePerHour,
+					OnDemandPrice: sc.OnDemandPricePerHour,
+				}
+				if perDollar {
+					row.CPUPerDollar = perDollarValue(sc.Resources.CPU, sc.CurrentMarketPricePerHour)
+					row.MemoryPerDollar = perDollarValue(sc.Resources.Memory, sc.CurrentMarketPricePerHour)
+				}
+				rows = append(rows, row)
+			}
+		}
+		if len(wanted) > 0 {
+			var missing []string
+			for name := range wanted {
+				missing = append(missing, name)
+			}
+			sort.Strings(missing)
+			return fmt.Errorf("serverclass(es) not found in region %s: %s", region, strings.Join(missing, ", "))
+		}
+
+		sort.SliceStable(rows, func(i, j int) bool {
+			a, b := bidValue(rows[i].MarketPrice), bidValue(rows[j].MarketPrice)
+			if a != b {
+				return a < b
+			}
+			return rows[i].ServerClass < rows[j].ServerClass
+		})
+		return internal.OutputData(rows, outputFormat)
+	},
+}
+
+// perDollarValue returns how much of a resource quantity such as "4" or "15GB"
+// one dollar per hour buys at the given price, or "-" when either cannot be parsed
+func perDollarValue(quantity, price string) string {
+	q := strings.TrimSpace(quantity)
+	end := 0
+	for end < len(q) && (q[end] == '.' || (q[end] >= '0' && q[end] <= '9')) {
+		end++
+	}
+	amount, err := strconv.ParseFloat(q[:end], 64)
+	if err != nil {
+		return "-"
+	}
+	p := bidValue(price)
+	if p <= 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(amount/p, 'f', 2, 64) + strings.TrimSpace(q[end:])
+}
+
 func init() {
 	rootCmd.AddCommand(pricingCmd)
 	pricingCmd.AddCommand(pricingGetCmd)
+	pricingCmd.AddCommand(pricingCompareCmd)
 	pricingGetCmd.Flags().String("serverclass", "", "Serverclass name")
+
+	pricingCompareCmd.Flags().StringP("region", "r", "", "Region name")
+	pricingCompareCmd.Flags().StringSlice("serverclass", nil, "Comma-separated serverclasses to compare")
+	pricingCompareCmd.Flags().Bool("all", false, "Compare all serverclasses in the region")
+	pricingCompareCmd.Flags().Bool("per-dollar", false, "Include CPU and memory per dollar of market price")
 }
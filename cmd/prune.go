@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+// prunedPool describes a node pool selected by 'nodepools prune'
+type prunedPool struct {
+	Name    string `json:"name" yaml:"name"`
+	Type    string `json:"type" yaml:"type"`
+	Desired int    `json:"desired" yaml:"desired"`
+	Ready   int    `json:"ready" yaml:"ready"`
+	Reason  string `json:"reason" yaml:"reason"`
+}
+
+// pruneReason returns why a pool with the given desired and ready node counts should be
+// pruned, or "" when it matches none of the enabled predicates
+func pruneReason(desired, ready int, zeroDesired, noReadyNodes bool) string {
+	var reasons []string
+	if zeroDesired && desired == 0 {
+		reasons = append(reasons, "zero desired")
+	}
+	if noReadyNodes && ready == 0 {
+		reasons = append(reasons, "no ready nodes")
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// nodepoolsPruneCmd represents the nodepools prune command
+var nodepoolsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete empty node pools",
+	Long: `Delete the spot and on-demand node pools of a cloudspace that are scaled to zero
+(--zero-desired) or have no ready nodes (--no-ready-nodes). A pool is pruned when it matches
+any of the given conditions.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		cloudspace, _ := cmd.Flags().GetString("cloudspace")
+		if cloudspace == "" {
+			return fmt.Errorf("cloudspace is required")
+		}
+		zeroDesired, _ := cmd.Flags().GetBool("zero-desired")
+		noReadyNodes, _ := cmd.Flags().GetBool("no-ready-nodes")
+		if !zeroDesired && !noReadyNodes {
+			return fmt.Errorf("specify at least one of --zero-desired or --no-ready-nodes")
+		}
+
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		ctx := cmd.Context()
+		spotPools, err := client.GetAPI().ListSpotNodePools(ctx, org, cloudspace)
+		if err != nil {
+			return fmt.Errorf("failed to list spot node pools: %w", err)
+		}
+		onDemandPools, err := client.GetAPI().ListOnDemandNodePools(ctx, org, cloudspace)
+		if err != nil {
+			return fmt.Errorf("failed to list on-demand node pools: %w", err)
+		}
+
+		var candidates []prunedPool
+		for _, p := range spotPools {
+			if reason := pruneReason(p.Desired, p.WonCount, zeroDesired, noReadyNodes); reason != "" {
+				candidates = append(candidates, prunedPool{Name: p.Name, Type: "spot", Desired: p.Desired, Ready: p.WonCount, Reason: reason})
+			}
+		}
+		for _, p := range onDemandPools {
+			if reason := pruneReason(p.Desired, p.WonCount, zeroDesired, noReadyNodes); reason != "" {
+				candidates = append(candidates, prunedPool{Name: p.Name, Type: "ondemand", Desired: p.Desired, Ready: p.WonCount, Reason: reason})
+			}
+		}
+		if len(candidates) == 0 {
+			fmt.Fprintf(os.Stderr, "No node pools to prune in cloudspace %s\n", cloudspace)
+			return nil
+		}
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			fmt.Fprintf(os.Stderr, "%d node pool(s) would be pruned (dry run)\n", len(candidates))
+			return internal.OutputData(candidates, outputFormat)
+		}
+		var names []string
+		byName := make(map[string]prunedPool, len(candidates))
+		for _, c := range candidates {
+			names = append(names, c.Name)
+			byName[c.Name] = c
+		}
+		if !confirmBatchDelete(cmd, "node pool", names) {
+			return nil
+		}
+
+		parallelism, _ := cmd.Flags().GetInt("parallelism")
+		summary := deleteInBatches(ctx, os.Stderr, "node pool", names, parallelism, func(ctx context.Context, name string) error {
+			if byName[name].Type == "spot" {
+				return client.GetAPI().DeleteSpotNodePool(ctx, org, name)
+			}
+			return client.GetAPI().DeleteOnDemandNodePool(ctx, org, name)
+		})
+		pruned := []prunedPool{}
+		for _, name := range summary.Succeeded {
+			pruned = append(pruned, byName[name])
+		}
+		return reportBatchResult(cmd, "node pool", summary, pruned)
+	},
+}
+
+func init() {
+	nodepoolsCmd.AddCommand(nodepoolsPruneCmd)
+
+	nodepoolsPruneCmd.Flags().String("org", "", "Organization ID")
+	nodepoolsPruneCmd.Flags().String("cloudspace", "", "Cloudspace to prune (required)")
+	nodepoolsPruneCmd.Flags().Bool("zero-desired", false, "Prune pools whose desired node count is zero")
+	nodepoolsPruneCmd.Flags().Bool("no-ready-nodes", false, "Prune pools without any ready nodes")
+	nodepoolsPruneCmd.Flags().BoolP("yes", "y", false, "Automatic yes to prompts; assume \"yes\" as answer")
+	nodepoolsPruneCmd.Flags().Bool("dry-run", false, "Print the pools that would be pruned without deleting them")
+	nodepoolsPruneCmd.Flags().Int("parallelism", defaultParallelism, "Maximum number of concurrent deletes")
+	nodepoolsPruneCmd.MarkFlagRequired("cloudspace")
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
 	"github.com/rackspace-spot/spotctl/internal"
 	config "github.com/rackspace-spot/spotctl/pkg"
 	"github.com/spf13/cobra"
@@ -59,15 +61,128 @@ var regionsGetCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
-		return internal.OutputData(regions, outputFormat)
+		withServerClasses, _ := cmd.Flags().GetBool("with-serverclasses")
+		if !withServerClasses {
+			return internal.OutputData(regions, outputFormat)
+		}
+		list, err := client.GetAPI().ListServerClasses(cmd.Context(), name)
+		if err != nil {
+			return fmt.Errorf("failed to list serverclasses for region %s: %w", name, err)
+		}
+		var classes []rxtspot.ServerClass
+		if list != nil {
+			classes = list.Items
+		}
+		return internal.OutputData(regionWithServerClasses{
+			Region:        regions,
+			ServerClasses: summarizeServerClasses(classes),
+		}, outputFormat)
 	},
 }
 
+// serverClassSummary describes the serverclasses offered in a region
+type serverClassSummary struct {
+	Count                   int     `json:"count" yaml:"count"`
+	Available               int     `json:"available" yaml:"available"`
+	GPUClasses              int     `json:"gpuClasses" yaml:"gpuClasses"`
+	MinMarketPricePerHour   float64 `json:"minMarketPricePerHour" yaml:"minMarketPricePerHour"`
+	MaxMarketPricePerHour   float64 `json:"maxMarketPricePerHour" yaml:"maxMarketPricePerHour"`
+	MinOnDemandPricePerHour float64 `json:"minOnDemandPricePerHour" yaml:"minOnDemandPricePerHour"`
+	MaxOnDemandPricePerHour float64 `json:"maxOnDemandPricePerHour" yaml:"maxOnDemandPricePerHour"`
+}
+
+// regionWithServerClasses is the output of 'regions get --with-serverclasses'
+type regionWithServerClasses struct {
+	Region        interface{}        `json:"region" yaml:"region"`
+	ServerClasses serverClassSummary `json:"serverClasses" yaml:"serverClasses"`
+}
+
+// summarizeServerClasses counts the classes and their GPU availability and computes the market
+// and on-demand price ranges. Classes without a parseable price are left out of the ranges.
+func summarizeServerClasses(classes []rxtspot.ServerClass) serverClassSummary {
+	s := serverClassSummary{Count: len(classes)}
+	var market, onDemand priceRange
+	for _, sc := range classes {
+		if serverClassAvailable(sc.Availability) {
+			s.Available++
+		}
+		if gpu := strings.TrimSpace(sc.Resources.GPU); gpu != "" && gpu != "0" {
+			s.GPUClasses++
+		}
+		market.add(bidValue(sc.CurrentMarketPricePerHour))
+		onDemand.add(bidValue(sc.OnDemandPricePerHour))
+	}
+	s.MinMarketPricePerHour, s.MaxMarketPricePerHour = market.lo, market.hi
+	s.MinOnDemandPricePerHour, s.MaxOnDemandPricePerHour = onDemand.lo, onDemand.hi
+	return s
+}
+
+// priceRange tracks the lowest and highest positive price seen
+type priceRange struct {
+	lo, hi float64
+}
+
+func (r *priceRange) add(p float64) {
+	if p <= 0 {
+		return
+	}
+	if r.lo == 0 || p < r.lo {
+		r.lo = p
+	}
+	if p > r.hi {
+		r.hi = p
+	}
+}
+
+// maxRegionSuggestionDistance is the largest edit distance at which a region is suggested
+const maxRegionSuggestionDistance = 3
+
+// suggestRegion returns the valid region closest to region by edit distance, if it is close
+// enough to be a likely typo
+func suggestRegion(region string) (string, bool) {
+	best, bestDist := "", maxRegionSuggestionDistance+1
+	for _, r := range validRegions {
+		if d := editDistance(strings.ToLower(region), r); d < bestDist {
+			best, bestDist = r, d
+		}
+	}
+	return best, best != ""
+}
+
+// invalidRegionError reports an unknown region, suggesting the closest valid one
+func invalidRegionError(region string) error {
+	if suggestion, ok := suggestRegion(region); ok {
+		return fmt.Errorf("region %s is not valid, did you mean '%s'? Available regions: %s", region, suggestion, strings.Join(validRegions, ", "))
+	}
+	return fmt.Errorf("region %s is not valid. Available regions: %s", region, strings.Join(validRegions, ", "))
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
 func init() {
 	rootCmd.AddCommand(regionsCmd)
 	regionsCmd.AddCommand(regionsListCmd)
 	regionsCmd.AddCommand(regionsGetCmd)
 
 	regionsGetCmd.Flags().String("name", "", "Region name")
-	regionsListCmd.Flags().StringP("output", "o", "json", "Output format (json, table, yaml)")
+	regionsGetCmd.Flags().Bool("with-serverclasses", false, "Include a summary of the region's serverclasses: count, availability, GPU classes and price ranges")
 }
@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
+	"runtime"
+	"runtime/debug"
 
+	"github.com/fatih/color"
+	"github.com/rackspace-spot/spotctl/internal"
+	"github.com/rackspace-spot/spotctl/internal/ui"
 	"github.com/rackspace-spot/spotctl/internal/version"
 	config "github.com/rackspace-spot/spotctl/pkg"
 
@@ -13,8 +20,24 @@ import (
 )
 
 var (
-	outputFormat string
-	verbosity    int
+	outputFormat   string
+	verbosity      int
+	noInput        bool
+	excludeColumns []string
+	httpDebug      bool
+	pinCertSHA256  string
+	showStats      bool
+	outputField    string
+	noColor        bool
+	quiet          bool
+	configDir      string
+	rawOutput      bool
+	validateOrg    bool
+	asciiOutput    bool
+	baseURL        string
+	authURL        string
+	noHeaders      bool
+	columns        []string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -69,12 +92,121 @@ func Execute() {
 	// Silence usage globally; let Cobra show usage only on flag/arg parsing errors
 	rootCmd.SilenceUsage = true
 	rootCmd.SilenceErrors = true // Stop Cobra from automatically showing usage on errors
-	if err := rootCmd.Execute(); err != nil {
+
+	// A panic while a prompt has the terminal in raw mode would leave the shell without echo
+	internal.SaveTerminalState()
+	defer func() {
+		if r := recover(); r != nil {
+			internal.RestoreTerminal()
+			fmt.Fprintf(os.Stderr, "panic: %v\n\n%s", r, debug.Stack())
+			os.Exit(2)
+		}
+	}()
+	err := rootCmd.Execute()
+	internal.RestoreTerminal()
+	if showStats {
+		internal.PrintHTTPStats(os.Stderr)
+	}
+	if err != nil {
 		// For all runtime errors, just print them cleanly
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		defer klog.Flush() // ensure logs are written before exit
-		os.Exit(1)
+		klog.Flush() // ensure logs are written before exit
+		code := 1
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.code
+		}
+		os.Exit(code)
+	}
+}
+
+// exitPartialSuccess is the exit code of a command that completed only part of its work,
+// e.g. 'cloudspaces create --no-rollback' with failed node pools
+const exitPartialSuccess = 3
+
+// exitError is an error that makes the process exit with a specific code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// exitAuthFailed is the exit code of auth and whoami when the stored credentials cannot
+// authenticate
+const exitAuthFailed = 4
+
+// validateEndpointURL checks that an endpoint flag is empty or an absolute http(s) URL
+func validateEndpointURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an http or https URL", value)
+	}
+	return nil
+}
+
+// configDefault returns a default taken from the config file, noting at -v 1 that it is being
+// used so that acting on a forgotten default is never silent
+func configDefault(what, value string) string {
+	if value != "" {
+		klog.V(1).Infof("using default %s %q from config", what, value)
+	}
+	return value
+}
+
+// resolveOrg returns the --org flag, falling back to the configured organization
+func resolveOrg(cmd *cobra.Command, cfg *config.SpotConfig) (string, error) {
+	org, _ := cmd.Flags().GetString("org")
+	if org == "" && cfg != nil {
+		org = configDefault("org", cfg.Org)
+	}
+	if org == "" {
+		return "", fmt.Errorf("organization not specified (use --org or run 'spotcli configure')")
+	}
+	return org, nil
+}
+
+// resolveRegion returns the --region flag, falling back to the configured region. The result
+// is empty when neither is set.
+func resolveRegion(cmd *cobra.Command, cfg *config.SpotConfig) string {
+	region, _ := cmd.Flags().GetString("region")
+	if region == "" && cfg != nil {
+		region = configDefault("region", cfg.Region)
+	}
+	return region
+}
+
+// resolveOutputFormat returns the output format to use: --output when given, then the
+// outputFormat of cfg, which may be nil, then the flag default. It is normalized once so every
+// comparison in the commands sees the same spelling.
+func resolveOutputFormat(cmd *cobra.Command, flagValue string, cfg *config.SpotConfig) (string, error) {
+	format := flagValue
+	if !cmd.Flags().Changed("output") && cfg != nil && cfg.OutputFormat != "" {
+		if err := internal.ValidateOutputFormat(cfg.OutputFormat); err != nil {
+			return "", fmt.Errorf("invalid outputFormat in config: %w", err)
+		}
+		format = cfg.OutputFormat
 	}
+	return internal.NormalizeOutputFormat(format), nil
+}
+
+// structuredOutput reports whether a command that prints a human-readable message by default
+// should print its result with OutputData instead, which is the case when --output is given
+// explicitly
+func structuredOutput(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("output")
 }
 
 func init() {
@@ -91,9 +223,108 @@ func init() {
 
 		// Optional: always log to stderr (otherwise klog can default to files)
 		flag.Set("logtostderr", "true")
+
+		config.SetConfigDir(configDir)
+		internal.SetHTTPDebug(httpDebug)
+
+		// Endpoints: flag > SPOT_BASE_URL/SPOT_AUTH_URL > default
+		for _, endpoint := range []struct{ flag, value string }{{"--base-url", baseURL}, {"--auth-url", authURL}} {
+			if err := validateEndpointURL(endpoint.value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid %s: %v\n", endpoint.flag, err)
+				os.Exit(1)
+			}
+		}
+		internal.SetEndpointURLs(baseURL, authURL)
+		if noColor {
+			color.NoColor = true
+		}
+		// Windows consoles render UTF-8 without advertising it through the locale variables
+		ui.SetASCII(asciiOutput || (runtime.GOOS != "windows" && !ui.LocaleSupportsUTF8()))
+		if showStats {
+			internal.EnableHTTPStats()
+		}
+
+		cfg, cfgErr := config.LoadConfig()
+
+		// Certificate pinning: flag > config file
+		pin := pinCertSHA256
+		if pin == "" && cfgErr == nil {
+			pin = cfg.PinCertSHA256
+		}
+		internal.SetPinnedCertSHA256(pin)
+
+		if cfgErr != nil {
+			cfg = nil
+		}
+		format, err := resolveOutputFormat(cmd, outputFormat, cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		outputFormat = format
+
+		if validateOrg {
+			if err := validateOrgFlag(cmd); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		internal.SetOutputField(outputField)
+		internal.SetRawOutput(rawOutput)
+		internal.SetTableOptions(internal.TableOptions{
+			Columns:        columns,
+			ExcludeColumns: excludeColumns,
+			NoHeaders:      noHeaders,
+		})
 	}
 
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format (json, table, yaml)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format (json, table, yaml, template-file=PATH, go-template=TEMPLATE, go-template-file=PATH)")
+	rootCmd.PersistentFlags().StringVar(&configDir, "config-dir", "", "Directory holding the config file (default $XDG_CONFIG_HOME/spotctl, also set by SPOTCTL_CONFIG_DIR)")
+	rootCmd.PersistentFlags().BoolVar(&rawOutput, "raw-output", false, "Print the unmodified result as indented JSON, ignoring --output and --field")
+	rootCmd.PersistentFlags().StringVar(&outputField, "field", "", "Print only the named top-level field of a single-object result (e.g. --field region)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors the NO_COLOR environment variable)")
+	rootCmd.PersistentFlags().BoolVar(&asciiOutput, "ascii", false, "Print ASCII markers such as [OK] and [FAIL] instead of Unicode symbols (default when LANG/LC_ALL is not UTF-8)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress progress output")
+	rootCmd.PersistentFlags().BoolVar(&noInput, "no-input", false, "Disable all interactive prompts")
+	rootCmd.PersistentFlags().BoolVar(&validateOrg, "validate-org", false, "Check that the organization is accessible before running the command")
+	rootCmd.PersistentFlags().BoolVar(&httpDebug, "http-debug", false, "Log every API request to stderr")
+	rootCmd.PersistentFlags().BoolVar(&showStats, "stats", false, "Print a summary of API calls, durations and bytes transferred to stderr when the command ends")
+	rootCmd.PersistentFlags().StringVar(&pinCertSHA256, "pin-cert-sha256", "", "Expected SHA-256 fingerprint of the API server certificate; connections presenting any other certificate are aborted")
+	rootCmd.PersistentFlags().StringVar(&baseURL, "base-url", "", "Spot API endpoint for this invocation (overrides SPOT_BASE_URL)")
+	rootCmd.PersistentFlags().StringVar(&authURL, "auth-url", "", "Spot OAuth endpoint for this invocation (overrides SPOT_AUTH_URL)")
+	rootCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", false, "Omit the header and separator lines from table output")
+	rootCmd.PersistentFlags().StringSliceVar(&columns, "columns", nil, "Comma-separated columns to show in table output, in that order (e.g. name,region,status)")
+	rootCmd.PersistentFlags().StringSliceVar(&excludeColumns, "exclude-columns", nil, "Comma-separated columns to hide from table output (e.g. message,preemptionWebhookURL)")
+}
+
+// validateOrgFlag checks the --org of commands that take one (or the configured default) against
+// the accessible organizations. Credential problems are left for the command itself to report.
+func validateOrgFlag(cmd *cobra.Command) error {
+	if cmd.Flags().Lookup("org") == nil {
+		return nil
+	}
+	cfg, err := config.GetCLIEssentials(cmd)
+	if err != nil {
+		return nil
+	}
+	org, _ := cmd.Flags().GetString("org")
+	if org == "" {
+		org = cfg.Org
+	}
+	if org == "" {
+		return nil
+	}
+	client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+	if err != nil {
+		return nil
+	}
+	return validateOrgAccess(cmd.Context(), client, org)
+}
+
+// canPrompt reports whether the user can be asked for input interactively
+func canPrompt() bool {
+	return !noInput && internal.IsTerminal(os.Stdin)
 }
 
 func initLoggingFlags(verbosity int) {
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+func TestResolveOutputFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		flag   string // value passed with -o; empty leaves the flag unset
+		config string // outputFormat in the config file
+		want   string
+	}{
+		{name: "default", want: "json"},
+		{name: "flag upper case", flag: "YAML", want: "yaml"},
+		{name: "flag mixed case", flag: "Table", want: "table"},
+		{name: "flag template path keeps case", flag: "Template-File=Reports/Pods.tmpl", want: "template-file=Reports/Pods.tmpl"},
+		{name: "config mixed case", config: "Table", want: "table"},
+		{name: "config template path keeps case", config: "template-file=~/Reports/Pods.tmpl", want: "template-file=~/Reports/Pods.tmpl"},
+		{name: "flag overrides config", flag: "YAML", config: "table", want: "yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			value := "json"
+			cmd.Flags().StringVarP(&value, "output", "o", "json", "")
+			if tt.flag != "" {
+				if err := cmd.Flags().Set("output", tt.flag); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var cfg *config.SpotConfig
+			if tt.config != "" {
+				cfg = &config.SpotConfig{OutputFormat: tt.config}
+			}
+			got, err := resolveOutputFormat(cmd, value, cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveOutputFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveOutputFormatRejectsInvalidConfig(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().StringP("output", "o", "json", "")
+	if _, err := resolveOutputFormat(cmd, "json", &config.SpotConfig{OutputFormat: "xml"}); err == nil {
+		t.Fatal("expected an error for an unsupported outputFormat in the config")
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// labelRequirement is a single term of a label selector
+type labelRequirement struct {
+	key    string
+	value  string
+	negate bool
+	exists bool
+}
+
+// labelSelector is a kubectl-style label selector such as "env=prod,team!=infra"
+type labelSelector []labelRequirement
+
+// parseLabelSelector parses a comma-separated selector of key=value, key==value,
+// key!=value and bare key (label present) terms
+func parseLabelSelector(s string) (labelSelector, error) {
+	var selector labelSelector
+	if strings.TrimSpace(s) == "" {
+		return selector, nil
+	}
+	for _, term := range strings.Split(s, ",") {
+		term = strings.TrimSpace(term)
+		var req labelRequirement
+		switch {
+		case strings.Contains(term, "!="):
+			parts := strings.SplitN(term, "!=", 2)
+			req = labelRequirement{key: parts[0], value: parts[1], negate: true}
+		case strings.Contains(term, "=="):
+			parts := strings.SplitN(term, "==", 2)
+			req = labelRequirement{key: parts[0], value: parts[1]}
+		case strings.Contains(term, "="):
+			parts := strings.SplitN(term, "=", 2)
+			req = labelRequirement{key: parts[0], value: parts[1]}
+		default:
+			req = labelRequirement{key: term, exists: true}
+		}
+		req.key = strings.TrimSpace(req.key)
+		req.value = strings.TrimSpace(req.value)
+		if req.key == "" {
+			return nil, fmt.Errorf("invalid label selector term %q", term)
+		}
+		selector = append(selector, req)
+	}
+	return selector, nil
+}
+
+// matches reports whether labels satisfy every term of the selector
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.key]
+		switch {
+		case req.exists:
+			if !ok {
+				return false
+			}
+		case req.negate:
+			if ok && value == req.value {
+				return false
+			}
+		default:
+			if !ok || value != req.value {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -3,7 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
 
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
 	"github.com/rackspace-spot/spotctl/internal"
 	config "github.com/rackspace-spot/spotctl/pkg"
 	"github.com/spf13/cobra"
@@ -31,27 +36,62 @@ var serverclassesListCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		region, _ := cmd.Flags().GetString("region")
-		if region == "" {
-			region = cfg.Region
+		region := resolveRegion(cmd, cfg)
+		contains, _ := cmd.Flags().GetString("contains")
+		family, _ := cmd.Flags().GetString("family")
+		availableOnly, _ := cmd.Flags().GetBool("available-only")
+		filter := func(name, availability string) bool {
+			return serverClassNameMatches(name, contains, family) && (!availableOnly || serverClassAvailable(availability))
+		}
+		if region == "all" {
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+			if parallelism < 1 {
+				return fmt.Errorf("parallelism must be at least 1")
+			}
+			return listServerClassesAllRegions(cmd.Context(), client, parallelism, filter)
 		}
 		if !isValidRegion(region) {
-			return fmt.Errorf("region %s is not valid. Available regions: %s, %s, %s, %s, %s, %s, %s, %s", region, US_CENTRAL_ORD_1, HKG_HKG_1, AUS_SYD_1, UK_LON_1, US_EAST_IAD_1, US_CENTRAL_DFW_1, US_CENTRAL_DFW_2, US_WEST_SJC_1)
+			return invalidRegionError(region)
 		}
 
 		serverclasses, err := client.GetAPI().ListServerClasses(context.Background(), region)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
+		if serverclasses != nil && (contains != "" || family != "" || availableOnly) {
+			filtered := *serverclasses
+			filtered.Items = serverclasses.Items[:0:0]
+			for _, sc := range serverclasses.Items {
+				if filter(sc.Name, sc.Availability) {
+					filtered.Items = append(filtered.Items, sc)
+				}
+			}
+			serverclasses = &filtered
+		}
 
 		return internal.OutputData(serverclasses, outputFormat)
 	},
 }
 
+// serverClassDetail is the table view of serverclasses get; prices are per hour
+type serverClassDetail struct {
+	Name          string `json:"name" yaml:"name"`
+	Region        string `json:"region" yaml:"region"`
+	Category      string `json:"category" yaml:"category"`
+	Availability  string `json:"availability" yaml:"availability"`
+	CPU           string `json:"cpu" yaml:"cpu"`
+	Memory        string `json:"memory" yaml:"memory"`
+	GPU           string `json:"gpu" yaml:"gpu"`
+	MarketPrice   string `json:"marketPrice" yaml:"marketPrice"`
+	MinBid        string `json:"minBid" yaml:"minBid"`
+	OnDemandPrice string `json:"onDemandPrice" yaml:"onDemandPrice"`
+}
+
 var serverclassesGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get serverclass",
-	Long:  `Get a specific serverclass.`,
+	Long: `Get a specific serverclass. Its prices are those of the region the serverclass belongs
+to, or of --region when given.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name, _ := cmd.Flags().GetString("name")
 
@@ -64,14 +104,204 @@ var serverclassesGetCmd = &cobra.Command{
 			return fmt.Errorf("%w", err)
 		}
 
-		serverclasses, err := client.GetAPI().GetServerClass(context.Background(), name)
+		serverclass, err := client.GetAPI().GetServerClass(context.Background(), name)
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
-		return internal.OutputData(serverclasses, outputFormat)
+
+		// Prices differ per region; take them from the listing of --region (which defaults to
+		// the configured region) or, when the class is not offered there, of its own region
+		region := resolveRegion(cmd, cfg)
+		if region != "" && !isValidRegion(region) {
+			return invalidRegionError(region)
+		}
+		if err := applyRegionPricing(cmd.Context(), client, serverclass, region); err != nil {
+			return err
+		}
+
+		// In table mode show the resources and prices as one flat key/value list
+		if strings.EqualFold(outputFormat, "table") {
+			return internal.OutputData(serverClassDetail{
+				Name:          serverclass.Name,
+				Region:        valueOrDash(serverclass.Region),
+				Category:      valueOrDash(serverclass.Category),
+				Availability:  valueOrDash(serverclass.Availability),
+				CPU:           valueOrDash(serverclass.Resources.CPU),
+				Memory:        valueOrDash(serverclass.Resources.Memory),
+				GPU:           valueOrDash(serverclass.Resources.GPU),
+				MarketPrice:   valueOrDash(serverclass.CurrentMarketPricePerHour),
+				MinBid:        valueOrDash(serverclass.MinBidPricePerHour),
+				OnDemandPrice: valueOrDash(serverclass.OnDemandPricePerHour),
+			}, outputFormat)
+		}
+		return internal.OutputData(serverclass, outputFormat)
 	},
 }
 
+// applyRegionPricing replaces the prices of sc with those listed for it in region, falling back
+// to the region of sc when region is empty or does not offer the class. The prices are left
+// unchanged when neither region lists it.
+func applyRegionPricing(ctx context.Context, client *internal.Client, sc *rxtspot.ServerClass, region string) error {
+	for _, r := range []string{region, sc.Region} {
+		if r == "" {
+			continue
+		}
+		list, err := client.ListServerClasses(ctx, r)
+		if err != nil {
+			return fmt.Errorf("failed to list serverclasses for region %s: %w", r, err)
+		}
+		for _, listed := range list.Items {
+			if listed.Name == sc.Name {
+				sc.Region = r
+				sc.CurrentMarketPricePerHour = listed.CurrentMarketPricePerHour
+				sc.MinBidPricePerHour = listed.MinBidPricePerHour
+				sc.OnDemandPricePerHour = listed.OnDemandPricePerHour
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// serverClassNameMatches reports whether a serverclass name contains the substring contains
+// and starts with family, ignoring case. Empty filters match every name.
+func serverClassNameMatches(name, contains, family string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, strings.ToLower(contains)) && strings.HasPrefix(name, strings.ToLower(family))
+}
+
+// serverClassAvailable reports whether an availability value from the API leaves capacity to
+// provision. Classes without an availability value are assumed to be available.
+func serverClassAvailable(availability string) bool {
+	switch strings.ToLower(strings.TrimSpace(availability)) {
+	case "unavailable", "soldout", "sold out", "sold-out", "none", "0", "false":
+		return false
+	}
+	return true
+}
+
+// listServerClassesAllRegions lists the serverclasses of every valid region concurrently and
+// prints them as one catalog with a region field, de-duplicated by region and class. Only
+// classes passing filter are included.
+func listServerClassesAllRegions(ctx context.Context, client *internal.Client, parallelism int, filter func(name, availability string) bool) error {
+	var (
+		mu       sync.Mutex
+		failures []string
+		seen     = make(map[string]bool)
+		items    = []map[string]interface{}{}
+	)
+	forEachLimit(len(validRegions), parallelism, func(i int) {
+		region := validRegions[i]
+		list, err := client.GetAPI().ListServerClasses(ctx, region)
+		var classes []map[string]interface{}
+		if err == nil && list != nil {
+			classes, err = toGenericMaps(list.Items)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("region %s: %v", region, err))
+			return
+		}
+		for _, sc := range classes {
+			name, _ := sc["name"].(string)
+			key := region + "/" + name
+			availability, _ := sc["availability"].(string)
+			if seen[key] || !filter(name, availability) {
+				continue
+			}
+			seen[key] = true
+			sc["region"] = region
+			items = append(items, sc)
+		}
+	})
+
+	// Concurrent collection is unordered; sort for stable output
+	sort.Slice(items, func(i, j int) bool {
+		ri, _ := items[i]["region"].(string)
+		rj, _ := items[j]["region"].(string)
+		if ri != rj {
+			return ri < rj
+		}
+		ni, _ := items[i]["name"].(string)
+		nj, _ := items[j]["name"].(string)
+		return ni < nj
+	})
+
+	if err := internal.OutputData(items, outputFormat); err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		for _, f := range failures {
+			fmt.Fprintf(os.Stderr, "Warning: failed to list serverclasses for %s\n", f)
+		}
+		return fmt.Errorf("serverclass listing incomplete: %d request(s) failed", len(failures))
+	}
+	return nil
+}
+
+// serverClassNames returns the sorted names of the server classes offered in region
+func serverClassNames(ctx context.Context, client *internal.Client, region string) ([]string, error) {
+	list, err := client.ListServerClasses(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list serverclasses for region %s: %w", region, err)
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, sc := range list.Items {
+		names = append(names, sc.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// validateServerClass checks that serverClass is offered in the region of the cloudspace
+func validateServerClass(ctx context.Context, client *internal.Client, org, cloudspace, serverClass string) error {
+	cs, err := client.GetCloudspace(ctx, org, cloudspace)
+	if err != nil {
+		return fmt.Errorf("failed to get cloudspace %s: %w", cloudspace, err)
+	}
+	names, err := serverClassNames(ctx, client, cs.Region)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == serverClass {
+			return nil
+		}
+	}
+	return fmt.Errorf("serverclass %q is not available in region %s. Valid serverclasses: %s", serverClass, cs.Region, strings.Join(names, ", "))
+}
+
+// completeServerClasses suggests the server classes of the --cloudspace region, or of the
+// configured region when no cloudspace is given yet
+func completeServerClasses(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.GetCLIEssentials(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	org, _ := cmd.Flags().GetString("org")
+	if org == "" {
+		org = cfg.Org
+	}
+	region := cfg.Region
+	if cloudspace, _ := cmd.Flags().GetString("cloudspace"); cloudspace != "" {
+		if cs, err := client.GetCloudspace(cmd.Context(), org, cloudspace); err == nil {
+			region = cs.Region
+		}
+	}
+	names, err := serverClassNames(cmd.Context(), client, region)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
 func init() {
 	rootCmd.AddCommand(serverclassesCmd)
 	serverclassesCmd.AddCommand(serverclassesListCmd)
@@ -79,7 +309,11 @@ func init() {
 
 	serverclassesGetCmd.Flags().String("name", "", "Serverclass name")
 	serverclassesGetCmd.MarkFlagRequired("name")
+	serverclassesGetCmd.Flags().StringP("region", "r", "", "Region whose prices to show (default: the configured region, or the region of the serverclass when it is not offered there)")
 
-	serverclassesListCmd.Flags().StringP("region", "r", "", "Region name")
-	serverclassesListCmd.Flags().StringP("output", "o", "json", "Output format (json, table, yaml)")
+	serverclassesListCmd.Flags().StringP("region", "r", "", "Region name, or \"all\" to list every region")
+	serverclassesListCmd.Flags().String("contains", "", "Only list serverclasses whose name contains this substring (e.g. medium)")
+	serverclassesListCmd.Flags().String("family", "", "Only list serverclasses whose name starts with this prefix (e.g. gp.vs1)")
+	serverclassesListCmd.Flags().Bool("available-only", false, "Hide serverclasses the API reports as unavailable in the region")
+	serverclassesListCmd.Flags().Int("parallelism", defaultParallelism, "Maximum number of concurrent API requests with --region all")
 }
@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+// topPool is one node pool row of the top dashboard
+type topPool struct {
+	kind        string
+	name        string
+	serverClass string
+	status      string
+	desired     int
+	ready       int
+	bid         float64
+	market      float64
+	hourly      float64
+	priced      bool
+}
+
+// health classifies a pool as "fail", "warn" or "ok" for coloring
+func (p topPool) health() string {
+	switch {
+	case isFailureStatus(p.status):
+		return "fail"
+	case p.ready < p.desired, p.kind == "spot" && marketPosition(p.bid, p.market) == "below":
+		return "warn"
+	}
+	return "ok"
+}
+
+// topSnapshot is the state of a cloudspace shown by one refresh of the dashboard
+type topSnapshot struct {
+	cloudspace *rxtspot.CloudSpace
+	pools      []topPool
+	fetchedAt  time.Time
+	// pricingErr is set when prices could not be fetched; the pools are still shown
+	pricingErr error
+}
+
+// fetchTopSnapshot reads a cloudspace and the current prices of its region. Prices are
+// fetched without the per-invocation cache since the dashboard runs for a long time.
+func fetchTopSnapshot(ctx context.Context, client *internal.Client, org, name string) (*topSnapshot, error) {
+	cs, err := client.GetAPI().GetCloudspace(ctx, org, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cloudspace %s: %w", name, err)
+	}
+	snap := &topSnapshot{cloudspace: cs, fetchedAt: time.Now()}
+
+	prices := map[string]internal.ServerClassPrice{}
+	if list, err := client.GetAPI().ListServerClasses(ctx, cs.Region); err != nil {
+		snap.pricingErr = err
+	} else if list != nil {
+		for _, sc := range list.Items {
+			prices[sc.Name] = internal.ServerClassPrice{
+				Market:   internal.ParsePrice(sc.CurrentMarketPricePerHour),
+				MinBid:   internal.ParsePrice(sc.MinBidPricePerHour),
+				OnDemand: internal.ParsePrice(sc.OnDemandPricePerHour),
+			}
+		}
+	}
+
+	for _, p := range cs.SpotNodepools {
+		if p == nil {
+			continue
+		}
+		pool := topPool{
+			kind:        "spot",
+			name:        poolDisplayName(p.Name, p.CustomLabels),
+			serverClass: p.ServerClass,
+			status:      p.Status,
+			desired:     p.Desired,
+			ready:       p.WonCount,
+			bid:         bidValue(p.BidPrice),
+			market:      prices[p.ServerClass].Market,
+		}
+		// Won spot nodes are billed at the market price
+		if pool.market > 0 {
+			pool.hourly, pool.priced = pool.market*float64(pool.ready), true
+		}
+		snap.pools = append(snap.pools, pool)
+	}
+	for _, p := range cs.OnDemandNodePools {
+		if p == nil {
+			continue
+		}
+		pool := topPool{
+			kind:        "ondemand",
+			name:        poolDisplayName(p.Name, p.CustomLabels),
+			serverClass: p.ServerClass,
+			status:      p.Status,
+			desired:     p.Desired,
+			ready:       p.WonCount,
+		}
+		price := internal.ParsePrice(p.OnDemandPricePerHour)
+		if price <= 0 {
+			price = prices[p.ServerClass].OnDemand
+		}
+		if price > 0 {
+			pool.hourly, pool.priced = price*float64(pool.ready), true
+		}
+		snap.pools = append(snap.pools, pool)
+	}
+	return snap, nil
+}
+
+// poolDisplayName prefers the "name" custom label of a pool over its UUID
+func poolDisplayName(name string, labels map[string]string) string {
+	if label := labels["name"]; label != "" {
+		return label
+	}
+	return name
+}
+
+// topSnapshotMsg delivers the result of a refresh to the dashboard
+type topSnapshotMsg struct {
+	snapshot *topSnapshot
+	err      error
+}
+
+// topTickMsg asks the dashboard to refresh. Ticks from an older generation were superseded
+// by a manual refresh and are dropped, so only one tick chain is ever running.
+type topTickMsg struct {
+	generation int
+}
+
+// topModel is the BubbleTea model of cloudspaces top
+type topModel struct {
+	ctx      context.Context
+	client   *internal.Client
+	org      string
+	name     string
+	interval time.Duration
+
+	snapshot   *topSnapshot
+	err        error
+	fetching   bool
+	generation int
+}
+
+// fetch returns a command that refreshes the snapshot in the background
+func (m *topModel) fetch() tea.Cmd {
+	m.fetching = true
+	return func() tea.Msg {
+		snap, err := fetchTopSnapshot(m.ctx, m.client, m.org, m.name)
+		return topSnapshotMsg{snapshot: snap, err: err}
+	}
+}
+
+func (m *topModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m *topModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "r":
+			if !m.fetching {
+				return m, m.fetch()
+			}
+		}
+	case topSnapshotMsg:
+		m.fetching = false
+		// Keep showing the last good snapshot when a refresh fails
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m.snapshot, m.err = msg.snapshot, nil
+		}
+		m.generation++
+		generation := m.generation
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg { return topTickMsg{generation: generation} })
+	case topTickMsg:
+		if msg.generation == m.generation && !m.fetching {
+			return m, m.fetch()
+		}
+	}
+	return m, nil
+}
+
+func (m *topModel) View() string {
+	var b strings.Builder
+	if m.snapshot == nil {
+		if m.err != nil {
+			fmt.Fprintf(&b, "%s\n\n", color.RedString("Error: %v", m.err))
+		} else {
+			fmt.Fprintf(&b, "Loading cloudspace %s...\n\n", m.name)
+		}
+		b.WriteString("q: quit\n")
+		return b.String()
+	}
+
+	snap := m.snapshot
+	cs := snap.cloudspace
+	fmt.Fprintf(&b, "Cloudspace %s  org %s  region %s  status %s\n", cs.Name, m.org, valueOrDash(cs.Region), colorizeHealth(healthOfStatus(cs.Status), valueOrDash(cs.Status)))
+	if cs.Message != "" {
+		fmt.Fprintf(&b, "%s\n", cs.Message)
+	}
+	b.WriteString("\n")
+
+	var desired, ready, unpriced int
+	var hourly float64
+	rows := [][]topCell{{{text: "KIND"}, {text: "POOL"}, {text: "SERVERCLASS"}, {text: "STATUS"}, {text: "READY/DESIRED"}, {text: "BID"}, {text: "MARKET"}, {text: "$/HOUR"}}}
+	for _, p := range snap.pools {
+		desired += p.desired
+		ready += p.ready
+		cost := "-"
+		if p.priced {
+			hourly += p.hourly
+			cost = fmt.Sprintf("%.3f", p.hourly)
+		} else {
+			unpriced++
+		}
+		bid, market := topCell{text: "-"}, topCell{text: "-"}
+		if p.kind == "spot" {
+			bid.text, market.text = priceOrDash(p.bid), priceOrDash(p.market)
+			if marketPosition(p.bid, p.market) == "below" {
+				bid.health = "fail"
+			}
+		}
+		rows = append(rows, []topCell{
+			{text: p.kind}, {text: p.name}, {text: p.serverClass},
+			{text: valueOrDash(p.status), health: healthOfStatus(p.status)},
+			{text: fmt.Sprintf("%d/%d", p.ready, p.desired), health: p.health()},
+			bid, market, {text: cost},
+		})
+	}
+	writeTopTable(&b, rows)
+	if len(snap.pools) == 0 {
+		b.WriteString("No node pools\n")
+	}
+
+	fmt.Fprintf(&b, "\n%d pools, %d/%d nodes ready, estimated spend $%.3f/hour (about $%.2f/month)", len(snap.pools), ready, desired, hourly, hourly*hoursPerMonth)
+	if unpriced > 0 {
+		fmt.Fprintf(&b, ", excluding %d pool(s) without pricing", unpriced)
+	}
+	b.WriteString("\n")
+	if snap.pricingErr != nil {
+		fmt.Fprintf(&b, "%s\n", color.YellowString("Warning: prices unavailable: %v", snap.pricingErr))
+	}
+	if m.err != nil {
+		fmt.Fprintf(&b, "%s\n", color.RedString("Refresh failed: %v", m.err))
+	}
+	fmt.Fprintf(&b, "\nUpdated %s, every %s  q: quit  r: refresh now\n", snap.fetchedAt.Format("15:04:05"), m.interval)
+	return b.String()
+}
+
+// topCell is one cell of the dashboard table, colored by its health when it has one
+type topCell struct {
+	text   string
+	health string
+}
+
+// writeTopTable writes rows as aligned columns. Padding is computed from the plain text and
+// colors are applied afterwards, since escape codes would otherwise count towards the width.
+func writeTopTable(b *strings.Builder, rows [][]topCell) {
+	var widths []int
+	for _, row := range rows {
+		for i, c := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], len([]rune(c.text)))
+		}
+	}
+	for _, row := range rows {
+		for i, c := range row {
+			if c.health != "" {
+				b.WriteString(colorizeHealth(c.health, c.text))
+			} else {
+				b.WriteString(c.text)
+			}
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-len([]rune(c.text))+2))
+			}
+		}
+		b.WriteString("\n")
+	}
+}
+
+// healthOfStatus classifies a cloudspace or pool status for coloring
+func healthOfStatus(status string) string {
+	switch strings.ToLower(status) {
+	case "":
+		return "warn"
+	case "ready", "running", "healthy", "active", "fulfilled", "succeeded":
+		return "ok"
+	}
+	if isFailureStatus(status) {
+		return "fail"
+	}
+	return "warn"
+}
+
+// colorizeHealth colors s green, yellow or red for an "ok", "warn" or "fail" health
+func colorizeHealth(health, s string) string {
+	switch health {
+	case "ok":
+		return color.GreenString(s)
+	case "fail":
+		return color.RedString(s)
+	}
+	return color.YellowString(s)
+}
+
+// priceOrDash formats a price, or "-" when it is unknown
+func priceOrDash(price float64) string {
+	if price <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.4f", price)
+}
+
+// cloudspacesTopCmd represents the cloudspaces top command
+var cloudspacesTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show a live dashboard of a cloudspace",
+	Long: `Show a dashboard of a cloudspace that refreshes periodically: its status, the ready and
+desired nodes of each node pool, spot bids against the current market price and the estimated
+spend of the ready nodes. Pools short of nodes or bidding below market are shown in yellow,
+failures in red. Press q or Ctrl+C to quit and r to refresh immediately.
+
+top needs an interactive terminal; use 'cloudspaces get --watch' or 'cloudspaces events
+--follow' from scripts.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			return fmt.Errorf("name is required")
+		}
+		if !internal.IsTerminal(os.Stdout) || !internal.IsTerminal(os.Stdin) {
+			return fmt.Errorf("top needs an interactive terminal (use 'cloudspaces get --watch' from scripts)")
+		}
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		org, err := resolveOrg(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		client, err := internal.NewClientWithTokens(cfg.RefreshToken, cfg.AccessToken)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+		// Fail before taking over the screen when the cloudspace does not exist
+		if _, err := client.GetAPI().GetCloudspace(cmd.Context(), org, name); err != nil {
+			return fmt.Errorf("failed to get cloudspace %s: %w", name, err)
+		}
+
+		model := &topModel{ctx: cmd.Context(), client: client, org: org, name: name, interval: interval}
+		_, err = internal.RunProgram(cmd.Context(), model, tea.WithAltScreen())
+		if err != nil && cmd.Context().Err() == nil {
+			return fmt.Errorf("dashboard failed: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cloudspacesCmd.AddCommand(cloudspacesTopCmd)
+
+	cloudspacesTopCmd.Flags().String("name", "", "Cloudspace name (required)")
+	cloudspacesTopCmd.Flags().String("org", "", "Organization ID")
+	cloudspacesTopCmd.Flags().Duration("interval", readyPollInterval, "Refresh interval")
+	cloudspacesTopCmd.MarkFlagRequired("name")
+}
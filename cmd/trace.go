@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// phaseTrace records how long each phase of a long-running command took
+type phaseTrace struct {
+	enabled bool
+	started time.Time
+	phases  []tracedPhase
+}
+
+type tracedPhase struct {
+	name    string
+	elapsed time.Duration
+}
+
+// newPhaseTrace returns a trace that only records phases when enabled
+func newPhaseTrace(enabled bool) *phaseTrace {
+	return &phaseTrace{enabled: enabled, started: time.Now()}
+}
+
+// track records the time elapsed since start under the given phase name
+func (t *phaseTrace) track(name string, start time.Time) {
+	if !t.enabled {
+		return
+	}
+	elapsed := time.Since(start)
+	t.phases = append(t.phases, tracedPhase{name: name, elapsed: elapsed})
+	klog.V(1).Infof("phase %q took %s", name, elapsed)
+}
+
+// print writes a summary table of all recorded phases
+func (t *phaseTrace) print(w io.Writer) {
+	if !t.enabled || len(t.phases) == 0 {
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nPHASE\tDURATION")
+	for _, p := range t.phases {
+		fmt.Fprintf(tw, "%s\t%s\n", p.name, p.elapsed.Round(time.Millisecond))
+	}
+	fmt.Fprintf(tw, "total\t%s\n", time.Since(t.started).Round(time.Millisecond))
+	tw.Flush()
+}
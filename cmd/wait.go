@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+	"github.com/rackspace-spot/spotctl/internal"
+	"github.com/rackspace-spot/spotctl/internal/ui"
+)
+
+// deletePollInterval is how often --wait-for-delete checks whether a resource is gone
+const deletePollInterval = 5 * time.Second
+
+// readyPollInterval is how often 'cloudspaces create --wait' checks the cloudspace status
+const readyPollInterval = 10 * time.Second
+
+// waitForDeletion polls get until it reports that the resource no longer exists or
+// timeout elapses. get must return an error satisfying rxtspot.IsNotFound once the resource
+// is gone, as client.CheckCloudspace and client.GetSpotNodePool do; the SDK getters' never do.
+func waitForDeletion(ctx context.Context, what string, timeout time.Duration, get func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	steps := ui.NewStepTracker(os.Stderr, 0, quiet)
+	steps.Start("Waiting for %s to be deleted", what)
+	ticker := time.NewTicker(deletePollInterval)
+	defer ticker.Stop()
+	for {
+		err := get(ctx)
+		if rxtspot.IsNotFound(err) {
+			steps.Done()
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return steps.Fail(fmt.Errorf("failed to check deletion of %s: %w", what, err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return steps.Fail(fmt.Errorf("timed out after %s waiting for %s to be deleted", timeout, what))
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForCloudspaceReady polls the cloudspace until its status is Ready or timeout elapses
+// and returns the ready cloudspace
+func waitForCloudspaceReady(ctx context.Context, client *internal.Client, org, name string, timeout time.Duration, steps *ui.StepTracker) (*rxtspot.CloudSpace, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	steps.Start("Waiting for cloudspace %s to become ready", name)
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+	status := ""
+	for {
+		cs, err := client.GetAPI().GetCloudspace(ctx, org, name)
+		if err != nil && ctx.Err() == nil {
+			return nil, steps.Fail(fmt.Errorf("failed to check status of cloudspace %s: %w", name, err))
+		}
+		if err == nil {
+			if strings.EqualFold(cs.Status, "ready") {
+				steps.Done()
+				return cs, nil
+			}
+			status = cs.Status
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.Canceled {
+				return nil, steps.Fail(fmt.Errorf("cancelled while waiting for cloudspace %s to become ready", name))
+			}
+			return nil, steps.Fail(fmt.Errorf("timed out after %s waiting for cloudspace %s to become ready (last status: %q)", timeout, name, status))
+		case <-ticker.C:
+		}
+	}
+}
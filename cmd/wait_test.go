@@ -0,0 +1,43 @@
+package cmd
+
+import "testing"
+
+func TestDeleteWaitsUntilNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		path string
+	}{
+		{
+			name: "cloudspace",
+			args: []string{"cloudspaces", "delete", "--name", "prod"},
+			path: "/apis/ngpc.rxt.io/v1/namespaces/" + fakeOrgNamespace + "/cloudspaces/prod",
+		},
+		{
+			name: "spot node pool",
+			args: []string{"nodepools", "spot", "delete", "--name", "pool-a"},
+			path: "/apis/ngpc.rxt.io/v1/namespaces/" + fakeOrgNamespace + "/spotnodepools/pool-a",
+		},
+		{
+			name: "on-demand node pool",
+			args: []string{"nodepools", "ondemand", "delete", "--name", "pool-b"},
+			path: "/apis/ngpc.rxt.io/v1/namespaces/" + fakeOrgNamespace + "/ondemandnodepools/pool-b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The fake API answers every GET of the deleted resource with a 404
+			api := newFakeAPI(t)
+			args := append(tt.args, "--yes", "--wait-for-delete", "--timeout", "1s")
+			if err := runCommand(t, args...); err != nil {
+				t.Fatalf("delete failed: %v", err)
+			}
+			if got := len(api.received("DELETE", tt.path)); got != 1 {
+				t.Errorf("got %d DELETE requests, want 1", got)
+			}
+			if got := len(api.received("GET", tt.path)); got == 0 {
+				t.Error("deletion was never checked")
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rackspace-spot/spotctl/internal"
+)
+
+// watchResource polls fetch every interval until interrupted. On a terminal each update
+// redraws the screen; when stdout is piped, JSON output is streamed as newline-delimited
+// JSON with one object per change so downstream tools can parse it incrementally.
+func watchResource(ctx context.Context, interval time.Duration, fetch func(ctx context.Context) (interface{}, error)) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	tty := internal.IsTerminal(os.Stdout)
+	ndjson := !tty && strings.EqualFold(outputFormat, "json")
+	var last []byte
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		data, err := fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		current, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode data: %w", err)
+		}
+		if !bytes.Equal(current, last) {
+			last = current
+			switch {
+			case ndjson:
+				fmt.Println(string(current))
+			case tty:
+				// Clear the screen and move the cursor home before redrawing
+				fmt.Print("\033[H\033[2J")
+				fmt.Printf("Every %s, last change at %s\n\n", interval, time.Now().Format(time.RFC1123))
+				if err := internal.OutputData(data, outputFormat); err != nil {
+					return err
+				}
+			default:
+				if err := internal.OutputData(data, outputFormat); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rackspace-spot/spotctl/internal"
+	config "github.com/rackspace-spot/spotctl/pkg"
+	"github.com/spf13/cobra"
+)
+
+// configSummary is the redacted view of the CLI configuration printed by configure and whoami
+type configSummary struct {
+	Org             string `json:"org" yaml:"org"`
+	Region          string `json:"region" yaml:"region"`
+	RefreshToken    string `json:"refreshToken" yaml:"refreshToken"`
+	PinCertSHA256   string `json:"pinCertSHA256,omitempty" yaml:"pinCertSHA256,omitempty"`
+	OutputFormat    string `json:"outputFormat,omitempty" yaml:"outputFormat,omitempty"`
+	CredentialStore string `json:"credentialStore,omitempty" yaml:"credentialStore,omitempty"`
+	ConfigFile      string `json:"configFile" yaml:"configFile"`
+	Authenticated   bool   `json:"authenticated" yaml:"authenticated"`
+	ExpiresAt       string `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	ExpiresIn       string `json:"expiresIn,omitempty" yaml:"expiresIn,omitempty"`
+}
+
+// newConfigSummary returns the redacted summary of cfg stored at path
+func newConfigSummary(cfg *config.SpotConfig, path string) configSummary {
+	return configSummary{
+		Org:             cfg.Org,
+		Region:          cfg.Region,
+		RefreshToken:    redactSecret(cfg.RefreshToken),
+		PinCertSHA256:   cfg.PinCertSHA256,
+		OutputFormat:    cfg.OutputFormat,
+		CredentialStore: cfg.CredentialStore,
+		ConfigFile:      path,
+	}
+}
+
+// redactSecret keeps only the last four characters of a secret
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the configured identity",
+	Long: `Show the organization, region and config file in use, whether the stored token can
+authenticate and when the resulting access token expires. Exits with code 4 when
+authentication fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.GetCLIEssentials(cmd)
+		if err != nil {
+			return err
+		}
+		path, err := config.GetConfigPath()
+		if err != nil {
+			return err
+		}
+		summary := newConfigSummary(cfg, path)
+
+		token, err := authenticate(cmd.Context(), cfg)
+		summary.Authenticated = err == nil
+		if err == nil {
+			summary.ExpiresAt, summary.ExpiresIn = tokenExpiry(token)
+		}
+		if outErr := internal.OutputData(summary, outputFormat); outErr != nil {
+			return outErr
+		}
+		if err != nil {
+			return &exitError{code: exitAuthFailed, err: fmt.Errorf("authentication failed: %w", err)}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+const (
+	// authAttempts is the number of times a transient authentication failure is tried
+	authAttempts = 3
+	// authBackoff is the delay before the first retry; it doubles on every attempt
+	authBackoff = 500 * time.Millisecond
+)
+
+// isInvalidTokenError reports whether err means the refresh token was rejected
+func isInvalidTokenError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"invalid_grant", "401", "unauthorized", "invalid refresh token", "token is expired"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// authStatusPattern matches the error the SDK returns when the token endpoint answers with a non-200 status
+var authStatusPattern = regexp.MustCompile(`^authentication failed: (\d{3}) `)
+
+// isTransientStatus reports whether an HTTP status code is worth retrying
+func isTransientStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// isTransientError reports whether err is a network or server-side failure worth retrying.
+// Certificate and TLS failures, including a pinning mismatch, are never retried.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var statusErr *rxtspot.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return isTransientStatus(statusErr.StatusCode)
+	}
+	if m := authStatusPattern.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return isTransientStatus(code)
+	}
+
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	var (
+		certErr     *tls.CertificateVerificationError
+		recordErr   tls.RecordHeaderError
+		alertErr    tls.AlertError
+		unknownCA   x509.UnknownAuthorityError
+		invalidCert x509.CertificateInvalidError
+		hostnameErr x509.HostnameError
+	)
+	if errors.As(err, &certErr) || errors.As(err, &recordErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &unknownCA) || errors.As(err, &invalidCert) || errors.As(err, &hostnameErr) {
+		return false
+	}
+	if urlErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		switch errno {
+		case syscall.ECONNREFUSED, syscall.ECONNRESET, syscall.ECONNABORTED, syscall.EHOSTUNREACH, syscall.ENETUNREACH, syscall.EPIPE:
+			return true
+		}
+		return false
+	}
+	// The server closed the connection before sending a response
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// authenticate authenticates against the Spot API, retrying transient failures with backoff,
+// and turns the final failure into an actionable error message
+func authenticate(ctx context.Context, api rxtspot.SpotAPI, oauthURL string) (string, error) {
+	var err error
+	backoff := authBackoff
+	for attempt := 1; attempt <= authAttempts; attempt++ {
+		var token string
+		token, err = api.Authenticate(ctx)
+		if err == nil {
+			return token, nil
+		}
+		if isInvalidTokenError(err) || !isTransientError(err) || attempt == authAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	// A wrong local clock makes valid tokens look expired or not yet valid
+	if !isTransientError(err) {
+		if hint := clockSkewHint(api); hint != "" {
+			return "", fmt.Errorf("authentication failed: %s: %w", hint, err)
+		}
+	}
+
+	switch {
+	case isInvalidTokenError(err):
+		return "", fmt.Errorf("authentication failed: the refresh token is invalid or expired, run 'spotctl configure' to set a new one: %w", err)
+	case isTransientError(err):
+		return "", fmt.Errorf("authentication failed: could not reach the auth service at %s after %d attempts, check your network connection or re-run with --http-debug: %w", oauthURL, authAttempts, err)
+	default:
+		return "", fmt.Errorf("failed to authenticate: %w", err)
+	}
+}
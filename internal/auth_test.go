@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+func TestIsTransientError(t *testing.T) {
+	wrapURL := func(err error) error {
+		return fmt.Errorf("request failed: %w", &url.Error{Op: "Post", URL: "https://login.spot.rackspace.com/oauth/token", Err: err})
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"http 503", &rxtspot.HTTPStatusError{StatusCode: 503}, true},
+		{"http 429", fmt.Errorf("list: %w", &rxtspot.HTTPStatusError{StatusCode: 429}), true},
+		{"http 404", &rxtspot.HTTPStatusError{StatusCode: 404, Body: "cloudspace prod-500 not found"}, false},
+		{"auth 502", errors.New("authentication failed: 502 Bad Gateway"), true},
+		{"auth 400", errors.New("authentication failed: 400 Bad Request"), false},
+		{"unrelated 500 in text", errors.New("bid price 0.500 is below the minimum"), false},
+		{"unrelated timeout in text", errors.New("invalid value for --timeout"), false},
+		{"connection refused", wrapURL(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), true},
+		{"connection reset", wrapURL(&net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"dial timeout", wrapURL(&net.DNSError{Err: "i/o timeout", Name: "login.spot.rackspace.com", IsTimeout: true}), true},
+		{"no such host", wrapURL(&net.DNSError{Err: "no such host", Name: "login.spot.rackspace.com", IsNotFound: true}), false},
+		{"eof", wrapURL(io.EOF), true},
+		{"unknown authority", wrapURL(x509.UnknownAuthorityError{}), false},
+		{"pin mismatch", wrapURL(errors.New("certificate pinning failed for spot.rackspace.com: expected sha256 aa, got bb")), false},
+		{"canceled", wrapURL(context.Canceled), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
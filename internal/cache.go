@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"sync"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+// cloudspaceKey identifies a cached cloudspace
+type cloudspaceKey struct {
+	org  string
+	name string
+}
+
+// cloudspaceCache holds the cloudspaces fetched during one invocation. It is safe for
+// concurrent use; callers that mutate a cloudspace must invalidate it.
+type cloudspaceCache struct {
+	mu      sync.Mutex
+	entries map[cloudspaceKey]*rxtspot.CloudSpace
+}
+
+// GetCloudspace returns the cloudspace, fetching it only on the first call for (org, name)
+// within this client. A missing cloudspace satisfies rxtspot.IsNotFound. Use
+// GetAPI().GetCloudspace when polling for changes.
+func (c *Client) GetCloudspace(ctx context.Context, org, name string) (*rxtspot.CloudSpace, error) {
+	key := cloudspaceKey{org: org, name: name}
+	c.cloudspaces.mu.Lock()
+	cs, ok := c.cloudspaces.entries[key]
+	c.cloudspaces.mu.Unlock()
+	if ok {
+		return cs, nil
+	}
+
+	cs, err := c.api.GetCloudspace(ctx, org, name)
+	if err != nil {
+		if checkErr := c.CheckCloudspace(ctx, org, name); rxtspot.IsNotFound(checkErr) {
+			return nil, checkErr
+		}
+		return nil, err
+	}
+	c.cloudspaces.mu.Lock()
+	if c.cloudspaces.entries == nil {
+		c.cloudspaces.entries = make(map[cloudspaceKey]*rxtspot.CloudSpace)
+	}
+	c.cloudspaces.entries[key] = cs
+	c.cloudspaces.mu.Unlock()
+	return cs, nil
+}
+
+// InvalidateCloudspace drops the cached cloudspace so the next GetCloudspace fetches it again
+func (c *Client) InvalidateCloudspace(org, name string) {
+	c.cloudspaces.mu.Lock()
+	defer c.cloudspaces.mu.Unlock()
+	delete(c.cloudspaces.entries, cloudspaceKey{org: org, name: name})
+}
+
+// organizationCache holds the organizations listed during one invocation
+type organizationCache struct {
+	mu      sync.Mutex
+	fetched bool
+	orgs    []rxtspot.Organization
+}
+
+// ListOrganizations returns the accessible organizations, fetching them only once per client
+func (c *Client) ListOrganizations(ctx context.Context) ([]rxtspot.Organization, error) {
+	c.orgs.mu.Lock()
+	defer c.orgs.mu.Unlock()
+	if c.orgs.fetched {
+		return c.orgs.orgs, nil
+	}
+	orgs, err := c.api.ListOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.orgs.orgs = orgs
+	c.orgs.fetched = true
+	return orgs, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
@@ -17,7 +18,12 @@ const (
 
 // Client wraps the Spot SDK client with CLI-specific functionality
 type Client struct {
-	api rxtspot.SpotAPI
+	api           rxtspot.SpotAPI
+	sdk           *rxtspot.RackspaceSpotClient
+	oauthURL      string
+	cloudspaces   cloudspaceCache
+	orgs          organizationCache
+	serverClasses serverClassCache
 }
 
 // ClientConfig holds configuration for creating a new Client
@@ -29,17 +35,36 @@ type ClientConfig struct {
 	Timeout      time.Duration
 }
 
-// DefaultConfig returns a default ClientConfig with sensible defaults
+// Endpoint overrides set from the --base-url and --auth-url flags
+var (
+	baseURLOverride string
+	authURLOverride string
+)
+
+// SetEndpointURLs overrides the API and OAuth endpoints of every client created afterwards.
+// Empty values leave the SPOT_BASE_URL/SPOT_AUTH_URL environment variables and the defaults
+// in effect.
+func SetEndpointURLs(baseURL, authURL string) {
+	baseURLOverride = strings.TrimRight(baseURL, "/")
+	authURLOverride = strings.TrimRight(authURL, "/")
+}
+
+// DefaultConfig returns a default ClientConfig with sensible defaults. Endpoints are taken
+// from SetEndpointURLs, then the SPOT_BASE_URL and SPOT_AUTH_URL environment variables.
 func DefaultConfig() ClientConfig {
 
 	var baseURL string
 	var authURL string
-	if os.Getenv("SPOT_BASE_URL") != "" {
+	if baseURLOverride != "" {
+		baseURL = baseURLOverride
+	} else if os.Getenv("SPOT_BASE_URL") != "" {
 		baseURL = os.Getenv("SPOT_BASE_URL")
 	} else {
 		baseURL = BaseURL
 	}
-	if os.Getenv("SPOT_AUTH_URL") != "" {
+	if authURLOverride != "" {
+		authURL = authURLOverride
+	} else if os.Getenv("SPOT_AUTH_URL") != "" {
 		authURL = os.Getenv("SPOT_AUTH_URL")
 	} else {
 		authURL = OAuthURL
@@ -58,10 +83,14 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 		return nil, fmt.Errorf("refresh token is required. Please run 'spotctl configure' to set it up")
 	}
 
+	transport, err := newTransport(cfg.BaseURL)
+	if err != nil {
+		return nil, err
+	}
 	sdkCfg := rxtspot.Config{
 		BaseURL:      cfg.BaseURL,
 		OAuthURL:     cfg.OAuthURL,
-		HTTPClient:   &http.Client{Timeout: cfg.Timeout},
+		HTTPClient:   &http.Client{Timeout: cfg.Timeout, Transport: transport},
 		RefreshToken: cfg.RefreshToken,
 		AccessToken:  cfg.AccessToken,
 	}
@@ -71,13 +100,15 @@ func NewClient(cfg ClientConfig) (*Client, error) {
 	}
 
 	// Let the SDK handle token validation and refresh
-	_, err = client.Authenticate(context.Background())
+	_, err = authenticate(context.Background(), client, cfg.OAuthURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate: %w", err)
+		return nil, err
 	}
 
 	return &Client{
-		api: client,
+		api:      client,
+		sdk:      client,
+		oauthURL: cfg.OAuthURL,
 	}, nil
 }
 
@@ -86,7 +117,7 @@ func (c *Client) GetAPI() rxtspot.SpotAPI {
 	return c.api
 }
 
-// Authenticate performs authentication
+// Authenticate performs authentication, retrying transient failures
 func (c *Client) Authenticate(ctx context.Context) (string, error) {
-	return c.api.Authenticate(ctx)
+	return authenticate(ctx, c.api, c.oauthURL)
 }
@@ -2,6 +2,20 @@ package internal
 
 // NewClientWithTokens is a convenience function to create a new client with just tokens
 func NewClientWithTokens(refreshToken, accessToken string) (*Client, error) {
+	return NewClientWithTokenSource(StaticTokenSource{Refresh: refreshToken, Access: accessToken})
+}
+
+// NewClientWithTokenSource creates a new client with the default configuration and the
+// tokens supplied by source
+func NewClientWithTokenSource(source TokenSource) (*Client, error) {
+	refreshToken, err := source.RefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := source.AccessToken()
+	if err != nil {
+		return nil, err
+	}
 	cfg := ClientConfig{
 		RefreshToken: refreshToken,
 		AccessToken:  accessToken,
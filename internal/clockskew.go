@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+// MaxClockSkew is the difference from the server's clock above which auth failures are
+// attributed to a wrong local clock
+const MaxClockSkew = 2 * time.Minute
+
+// serverClock remembers the offset between the local clock and the Date header of the most
+// recent API response
+var serverClock struct {
+	mu     sync.Mutex
+	offset time.Duration
+	known  bool
+}
+
+// clockTransport records the server time reported by each response
+type clockTransport struct {
+	next http.RoundTripper
+}
+
+func (t *clockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if date, perr := http.ParseTime(resp.Header.Get("Date")); perr == nil {
+		serverClock.mu.Lock()
+		serverClock.offset = time.Since(date)
+		serverClock.known = true
+		serverClock.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// jwtClaims holds the registered time claims of a JWT
+type jwtClaims struct {
+	IssuedAt  int64 `json:"iat"`
+	NotBefore int64 `json:"nbf"`
+	ExpiresAt int64 `json:"exp"`
+}
+
+// decodeJWTClaims decodes the time claims of a JWT without verifying its signature. It
+// returns false for opaque tokens.
+func decodeJWTClaims(token string) (jwtClaims, bool) {
+	var claims jwtClaims
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, false
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, false
+	}
+	return claims, true
+}
+
+// TokenExpiry returns when a JWT expires, or false when token is opaque or has no exp claim
+func TokenExpiry(token string) (time.Time, bool) {
+	claims, ok := decodeJWTClaims(token)
+	if !ok || claims.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.ExpiresAt, 0), true
+}
+
+// tokenIssuedAhead returns how far in the future a JWT was issued or becomes valid relative to
+// the local clock. Opaque tokens and tokens issued in the past return 0.
+func tokenIssuedAhead(token string, now time.Time) time.Duration {
+	claims, ok := decodeJWTClaims(token)
+	if !ok {
+		return 0
+	}
+	var ahead time.Duration
+	for _, ts := range []int64{claims.IssuedAt, claims.NotBefore} {
+		if ts == 0 {
+			continue
+		}
+		if d := time.Unix(ts, 0).Sub(now); d > ahead {
+			ahead = d
+		}
+	}
+	return ahead
+}
+
+// clockSkewHint explains an authentication failure caused by a wrong local clock, judged by
+// the server's Date header and the iat/nbf claims of the client's tokens. It returns "" when
+// the clock looks right.
+func clockSkewHint(api rxtspot.SpotAPI) string {
+	var skew time.Duration
+	serverClock.mu.Lock()
+	if serverClock.known {
+		skew = serverClock.offset
+	}
+	serverClock.mu.Unlock()
+
+	if c, ok := api.(*rxtspot.RackspaceSpotClient); ok && skew.Abs() <= MaxClockSkew {
+		now := time.Now()
+		for _, token := range []string{c.Token, c.RefreshToken} {
+			if ahead := tokenIssuedAhead(token, now); ahead > MaxClockSkew {
+				skew = -ahead
+			}
+		}
+	}
+	if skew.Abs() <= MaxClockSkew {
+		return ""
+	}
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	return fmt.Sprintf("the local clock is %s %s the server's; check the system clock and NTP synchronization", skew.Abs().Round(time.Second), direction)
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+// CheckCloudspace returns nil when the cloudspace exists. Unlike the errors of the SDK's
+// GetCloudspace, the error for a missing cloudspace satisfies rxtspot.IsNotFound.
+func (c *Client) CheckCloudspace(ctx context.Context, org, name string) error {
+	if err := c.resourceRequest(ctx, http.MethodGet, org, "cloudspaces", name, nil, nil); err != nil {
+		return getError(err, "cloudspace", name)
+	}
+	return nil
+}
+
+// CreateCloudspace creates a cloudspace with the same request as the SDK's CreateCloudspace.
+// Unlike the SDK's, its errors keep the status code, so a name that is already taken
+// satisfies rxtspot.IsConflict.
+func (c *Client) CreateCloudspace(ctx context.Context, cs rxtspot.CloudSpace) error {
+	if err := rxtspot.ValidateResourceName(cs.Name); err != nil {
+		return fmt.Errorf("invalid cloudspace name: %w", err)
+	}
+	orgID, err := c.orgNamespace(ctx, cs.Org)
+	if err != nil {
+		return err
+	}
+
+	var body rxtspot.CloudSpaceCreateRequestBody
+	body.APIVersion = "ngpc.rxt.io/v1"
+	body.Kind = "CloudSpace"
+	body.Metadata.Name = cs.Name
+	body.Metadata.Namespace = orgID
+	body.Metadata.Annotations = map[string]string{}
+	body.Spec.DeploymentType = "gen2"
+	body.Spec.Cloud = "default"
+	body.Spec.Region = cs.Region
+	body.Spec.Webhook = cs.PreemptionWebhookURL
+	body.Spec.CNI = cs.CNI
+	body.Spec.KubernetesVersion = cs.KubernetesVersion
+	body.Spec.GpuEnabled = cs.GpuEnabled
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cloudspace: %w", err)
+	}
+	return c.resourceRequest(ctx, http.MethodPost, cs.Org, "cloudspaces", "", data, nil)
+}
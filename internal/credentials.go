@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/zalando/go-keyring"
+)
+
+// TokenSource supplies the credentials a Client authenticates with, so that the refresh token
+// can live somewhere other than the config file without changing the commands using it
+type TokenSource interface {
+	// RefreshToken returns the long-lived refresh token, or "" when the source has none
+	RefreshToken() (string, error)
+	// AccessToken returns a cached access token, or "" when there is none
+	AccessToken() (string, error)
+}
+
+// StaticTokenSource is a TokenSource for tokens that are already known, such as those read
+// from the config file or typed into 'spotctl configure'
+type StaticTokenSource struct {
+	Refresh string
+	Access  string
+}
+
+// RefreshToken returns the stored refresh token
+func (s StaticTokenSource) RefreshToken() (string, error) {
+	return s.Refresh, nil
+}
+
+// AccessToken returns the stored access token
+func (s StaticTokenSource) AccessToken() (string, error) {
+	return s.Access, nil
+}
+
+// EnvTokenSource takes the refresh token from the SPOT_REFRESH_TOKEN environment variable,
+// falling back to Fallback when it is unset. This is the default source, with Fallback
+// holding the tokens of the config file.
+type EnvTokenSource struct {
+	Fallback TokenSource
+}
+
+// RefreshToken returns SPOT_REFRESH_TOKEN, or the refresh token of Fallback
+func (s EnvTokenSource) RefreshToken() (string, error) {
+	if token := os.Getenv("SPOT_REFRESH_TOKEN"); token != "" {
+		return token, nil
+	}
+	if s.Fallback == nil {
+		return "", nil
+	}
+	return s.Fallback.RefreshToken()
+}
+
+// AccessToken returns the access token of Fallback, unless SPOT_REFRESH_TOKEN replaces the
+// refresh token that access token was issued for
+func (s EnvTokenSource) AccessToken() (string, error) {
+	if s.Fallback == nil {
+		return "", nil
+	}
+	if token := os.Getenv("SPOT_REFRESH_TOKEN"); token != "" {
+		stored, err := s.Fallback.RefreshToken()
+		if err != nil || stored != token {
+			return "", nil
+		}
+	}
+	return s.Fallback.AccessToken()
+}
+
+// KeyringService is the service name spotctl credentials are stored under in the OS keyring
+const KeyringService = "spotctl"
+
+// KeyringTokenSource keeps the refresh token in the OS keyring: the macOS Keychain, the
+// Windows Credential Manager or the Secret Service on Linux. Account distinguishes the
+// credentials of different config files. Access tokens are short-lived and not stored.
+type KeyringTokenSource struct {
+	Account string
+}
+
+// RefreshToken reads the refresh token from the keyring
+func (s KeyringTokenSource) RefreshToken() (string, error) {
+	token, err := keyring.Get(KeyringService, s.Account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the refresh token from the OS keyring: %w", err)
+	}
+	return token, nil
+}
+
+// AccessToken always returns "", so every client exchanges the refresh token for a new one
+func (s KeyringTokenSource) AccessToken() (string, error) {
+	return "", nil
+}
+
+// StoreRefreshToken saves token in the keyring, replacing any token stored before
+func (s KeyringTokenSource) StoreRefreshToken(token string) error {
+	if err := keyring.Set(KeyringService, s.Account, token); err != nil {
+		return fmt.Errorf("failed to store the refresh token in the OS keyring: %w", err)
+	}
+	return nil
+}
+
+// DeleteRefreshToken removes the refresh token from the keyring. It is not an error when
+// no token is stored.
+func (s KeyringTokenSource) DeleteRefreshToken() error {
+	if err := keyring.Delete(KeyringService, s.Account); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("failed to remove the refresh token from the OS keyring: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+// The SDK's node pool getters and lists never read spec.autoscaling, so the pools they return
+// always have autoscaling disabled, and their errors cannot be matched with rxtspot.IsNotFound.
+// The methods below read the same resources through rawRequest instead.
+
+// nodePoolAutoscaling is the autoscaling spec of a node pool as returned by the API
+type nodePoolAutoscaling struct {
+	Enabled  bool  `json:"enabled"`
+	MinNodes int64 `json:"minNodes"`
+	MaxNodes int64 `json:"maxNodes"`
+}
+
+// onDemandNodePoolObject is an on-demand node pool as returned by the API, including the
+// autoscaling spec that rxtspot.OnDemandNodePoolGetResponse leaves out
+type onDemandNodePoolObject struct {
+	Metadata rxtspot.ResourceMetadataWithTimestamp `json:"metadata"`
+	Spec     struct {
+		rxtspot.OnDemandNodePoolSpecReadOnly
+		Autoscaling nodePoolAutoscaling `json:"autoscaling"`
+	} `json:"spec"`
+	Status rxtspot.OnDemandNodePoolStatus `json:"status"`
+}
+
+// onDemandNodePoolListObject is a page of on-demand node pools as returned by the API
+type onDemandNodePoolListObject struct {
+	Items    []onDemandNodePoolObject `json:"items"`
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+}
+
+// notFoundError reads like the SDK's "not found" errors but still wraps the
+// *rxtspot.HTTPStatusError, so that rxtspot.IsNotFound works on it
+type notFoundError struct {
+	msg string
+	err error
+}
+
+func (e *notFoundError) Error() string { return e.msg }
+
+func (e *notFoundError) Unwrap() error { return e.err }
+
+// getError describes a failed raw GET of the resource kind called name
+func getError(err error, kind, name string) error {
+	if rxtspot.IsNotFound(err) {
+		return &notFoundError{msg: fmt.Sprintf("%s '%s' not found", kind, name), err: err}
+	}
+	return fmt.Errorf("failed to get %s %s: %w", kind, name, err)
+}
+
+// spotNodePoolFromObject converts an API spot node pool the way the SDK does, keeping autoscaling
+func spotNodePoolFromObject(org string, obj rxtspot.SpotNodePoolGetResponse) *rxtspot.SpotNodePool {
+	cloudspace := obj.Spec.CloudSpace
+	if cloudspace == "" {
+		cloudspace = obj.Metadata.Labels["ngpc.rxt.io/cloudspace"]
+	}
+	pool := &rxtspot.SpotNodePool{
+		Name:              obj.Metadata.Name,
+		CreationTimestamp: obj.Metadata.CreationTimestamp,
+		CustomAnnotations: obj.Spec.CustomAnnotations,
+		CustomLabels:      obj.Spec.CustomLabels,
+		CustomTaints:      obj.Spec.CustomTaints,
+		Org:               org,
+		Cloudspace:        cloudspace,
+		ServerClass:       obj.Spec.ServerClass,
+		Desired:           obj.Spec.Desired,
+		BidPrice:          "$" + obj.Spec.BidPrice,
+		WonCount:          obj.Status.WonCount,
+		Status:            obj.Status.BidStatus,
+	}
+	pool.Autoscaling.Enabled = obj.Spec.Autoscaling.Enabled
+	pool.Autoscaling.MinNodes = int64(obj.Spec.Autoscaling.MinNodes)
+	pool.Autoscaling.MaxNodes = int64(obj.Spec.Autoscaling.MaxNodes)
+	return pool
+}
+
+// onDemandNodePoolFromObject converts an API on-demand node pool the way the SDK does, keeping
+// autoscaling. OnDemandPricePerHour is left empty.
+func onDemandNodePoolFromObject(org string, obj onDemandNodePoolObject) *rxtspot.OnDemandNodePool {
+	cloudspace := obj.Spec.CloudSpace
+	if cloudspace == "" {
+		cloudspace = obj.Metadata.Labels["ngpc.rxt.io/cloudspace"]
+	}
+	pool := &rxtspot.OnDemandNodePool{
+		Name:              obj.Metadata.Name,
+		CreationTimestamp: obj.Metadata.CreationTimestamp,
+		CustomAnnotations: obj.Spec.CustomAnnotations,
+		CustomLabels:      obj.Spec.CustomLabels,
+		CustomTaints:      obj.Spec.CustomTaints,
+		Org:               org,
+		Cloudspace:        cloudspace,
+		ServerClass:       obj.Spec.ServerClass,
+		Desired:           obj.Spec.Desired,
+		WonCount:          obj.Status.ReservedCount,
+		Status:            obj.Status.ReservedStatus,
+	}
+	pool.Autoscaling.Enabled = obj.Spec.Autoscaling.Enabled
+	pool.Autoscaling.MinNodes = int(obj.Spec.Autoscaling.MinNodes)
+	pool.Autoscaling.MaxNodes = int(obj.Spec.Autoscaling.MaxNodes)
+	return pool
+}
+
+// GetSpotNodePool returns a spot node pool like the SDK's GetSpotNodePool, including its
+// autoscaling settings. A missing pool satisfies rxtspot.IsNotFound.
+func (c *Client) GetSpotNodePool(ctx context.Context, org, name string) (*rxtspot.SpotNodePool, error) {
+	var obj rxtspot.SpotNodePoolGetResponse
+	if err := c.nodePoolRequest(ctx, http.MethodGet, org, name, true, nil, &obj); err != nil {
+		return nil, getError(err, "spot node pool", name)
+	}
+	return spotNodePoolFromObject(org, obj), nil
+}
+
+// GetOnDemandNodePool returns an on-demand node pool like the SDK's GetOnDemandNodePool,
+// including its autoscaling settings. A missing pool satisfies rxtspot.IsNotFound.
+func (c *Client) GetOnDemandNodePool(ctx context.Context, org, name string) (*rxtspot.OnDemandNodePool, error) {
+	var obj onDemandNodePoolObject
+	if err := c.nodePoolRequest(ctx, http.MethodGet, org, name, false, nil, &obj); err != nil {
+		return nil, getError(err, "on-demand node pool", name)
+	}
+	pool := onDemandNodePoolFromObject(org, obj)
+	c.fillOnDemandPrices(ctx, []*rxtspot.OnDemandNodePool{pool})
+	return pool, nil
+}
+
+// ListSpotNodePools returns every spot node pool of a cloudspace like the SDK's
+// ListSpotNodePools, including their autoscaling settings
+func (c *Client) ListSpotNodePools(ctx context.Context, org, cloudspace string) ([]*rxtspot.SpotNodePool, error) {
+	var pools []*rxtspot.SpotNodePool
+	err := c.ListSpotNodePoolPages(ctx, org, cloudspace, func(page []*rxtspot.SpotNodePool) error {
+		pools = append(pools, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+// ListOnDemandNodePools returns every on-demand node pool of a cloudspace like the SDK's
+// ListOnDemandNodePools, including their autoscaling settings
+func (c *Client) ListOnDemandNodePools(ctx context.Context, org, cloudspace string) ([]*rxtspot.OnDemandNodePool, error) {
+	var pools []*rxtspot.OnDemandNodePool
+	err := c.ListOnDemandNodePoolPages(ctx, org, cloudspace, func(page []*rxtspot.OnDemandNodePool) error {
+		pools = append(pools, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.fillOnDemandPrices(ctx, pools)
+	return pools, nil
+}
+
+// fillOnDemandPrices sets OnDemandPricePerHour from the pools' server classes, looking each
+// class up once. Pools whose server class cannot be read keep an empty price.
+func (c *Client) fillOnDemandPrices(ctx context.Context, pools []*rxtspot.OnDemandNodePool) {
+	prices := map[string]string{}
+	for _, p := range pools {
+		price, ok := prices[p.ServerClass]
+		if !ok {
+			if sc, err := c.api.GetServerClass(ctx, p.ServerClass); err == nil {
+				price = sc.OnDemandPricePerHour
+			}
+			prices[p.ServerClass] = price
+		}
+		p.OnDemandPricePerHour = price
+	}
+}
@@ -1,18 +1,129 @@
 package internal
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+	"unicode/utf8"
 
+	"github.com/Masterminds/sprig/v3"
+	"github.com/fatih/color"
 	"gopkg.in/yaml.v3"
 )
 
-// OutputData formats and prints data according to the specified format
-func OutputData(data interface{}, format string) error {
+// TableOptions controls how table output is rendered
+type TableOptions struct {
+	// Columns lists the only columns to show, in that order, matched like ExcludeColumns
+	Columns []string
+	// ExcludeColumns lists columns to drop, matched case-insensitively against JSON tags and field names
+	ExcludeColumns []string
+	// NoHeaders omits the header and dashed separator lines, leaving only the rows
+	NoHeaders bool
+}
+
+var (
+	tableOptions TableOptions
+	outputField  string
+	rawOutput    bool
+)
+
+// TableTotals is a rollup rendered under the rows of a table. Row is a value of the row type
+// whose fields are printed as the totals line, and each Summary line is printed after it.
+type TableTotals struct {
+	Row     interface{}
+	Summary []string
+}
+
+// SetTableOptions configures table rendering for subsequent OutputData calls
+func SetTableOptions(opts TableOptions) {
+	tableOptions = opts
+}
+
+// SetRawOutput makes OutputData always print indented JSON, ignoring the format and --field
+func SetRawOutput(enabled bool) {
+	rawOutput = enabled
+}
+
+// SetOutputField makes OutputData print only the named top-level field of a single-object result
+func SetOutputField(name string) {
+	outputField = name
+}
+
+// parameterizedFormats are the output formats taking a value after "=", which keeps its case
+var parameterizedFormats = []string{"template-file=", "go-template-file=", "go-template="}
+
+// NormalizeOutputFormat trims format and lowercases it, so "JSON" and " yaml" select the same
+// formats as "json" and "yaml". The path or template of a parameterized format keeps its case.
+func NormalizeOutputFormat(format string) string {
+	format = strings.TrimSpace(format)
+	for _, prefix := range parameterizedFormats {
+		if len(format) >= len(prefix) && strings.EqualFold(format[:len(prefix)], prefix) {
+			return prefix + format[len(prefix):]
+		}
+	}
+	return strings.ToLower(format)
+}
+
+// ValidateOutputFormat reports whether format is one OutputData understands
+func ValidateOutputFormat(format string) error {
+	format = NormalizeOutputFormat(format)
+	if path, ok := strings.CutPrefix(format, "template-file="); ok {
+		if path == "" {
+			return fmt.Errorf("template-file output requires a path (e.g. template-file=report.tmpl)")
+		}
+		return nil
+	}
+	if path, ok := strings.CutPrefix(format, "go-template-file="); ok {
+		if path == "" {
+			return fmt.Errorf("go-template-file output requires a path (e.g. go-template-file=report.tmpl)")
+		}
+		return nil
+	}
+	if text, ok := strings.CutPrefix(format, "go-template="); ok {
+		if text == "" {
+			return fmt.Errorf("go-template output requires a template (e.g. go-template='{{.Name}}')")
+		}
+		return nil
+	}
 	switch strings.ToLower(format) {
+	case "json", "yaml", "table":
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q (must be json, table, yaml, template-file=PATH, go-template=TEMPLATE or go-template-file=PATH)", format)
+}
+
+// OutputData formats and prints data according to the specified format.
+//
+// The rendering is a contract scripts may rely on. json and yaml print data as the encoders
+// marshal it, with struct fields in declaration order and map keys sorted. table prints one
+// row per element of a slice (or of the Items of a list wrapper), a FIELD/VALUE row per field
+// of a single struct or map, and "No data found" for an empty slice or a nil pointer. Table
+// cells follow formatCell.
+func OutputData(data interface{}, format string) error {
+	if rawOutput {
+		return outputJSON(data)
+	}
+	if outputField != "" {
+		return outputSingleField(data, outputField)
+	}
+	format = NormalizeOutputFormat(format)
+	if path, ok := strings.CutPrefix(format, "template-file="); ok {
+		return outputTemplateFile(data, path)
+	}
+	if path, ok := strings.CutPrefix(format, "go-template-file="); ok {
+		return outputGoTemplateFile(data, path)
+	}
+	if text, ok := strings.CutPrefix(format, "go-template="); ok {
+		return outputGoTemplate(data, "go-template", text)
+	}
+	switch format {
 	case "json":
 		return outputJSON(data)
 	case "yaml":
@@ -24,48 +135,211 @@ func OutputData(data interface{}, format string) error {
 	}
 }
 
+// OutputDataWithTotals is OutputData with a totals rollup printed under the rows in table
+// format. Other formats print data alone, so the structured output is unchanged.
+func OutputDataWithTotals(data interface{}, totals TableTotals, format string) error {
+	if rawOutput || outputField != "" || NormalizeOutputFormat(format) != "table" {
+		return OutputData(data, format)
+	}
+	v := reflect.Indirect(reflect.ValueOf(data))
+	if v.Kind() != reflect.Slice {
+		return outputTable(data)
+	}
+	return outputSliceAsTable(v, &totals)
+}
+
+// outputJSON writes data as indented JSON. Struct fields keep their declaration order and
+// encoding/json always emits map keys sorted, so repeated runs produce diffable output.
 func outputJSON(data interface{}) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
 
+// outputYAML writes data as YAML. Like outputJSON the result is deterministic: the yaml.v3
+// encoder sorts map keys before emitting them.
 func outputYAML(data interface{}) error {
 	encoder := yaml.NewEncoder(os.Stdout)
 	defer encoder.Close()
 	return encoder.Encode(data)
 }
 
+// outputSingleField prints the value of one top-level field, matched case-insensitively
+// against the JSON names of data. Strings are printed raw, other values as compact JSON.
+func outputSingleField(data interface{}, field string) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode data: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("failed to decode data: %w", err)
+	}
+	obj, ok := generic.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("--field requires a single object result, got a list")
+	}
+	for _, key := range sortedKeys(obj) {
+		if !strings.EqualFold(key, field) {
+			continue
+		}
+		if s, ok := obj[key].(string); ok {
+			fmt.Println(s)
+			return nil
+		}
+		value, err := json.Marshal(obj[key])
+		if err != nil {
+			return fmt.Errorf("failed to encode field %s: %w", key, err)
+		}
+		fmt.Println(string(value))
+		return nil
+	}
+	return fmt.Errorf("field %q not found (available fields: %s)", field, strings.Join(sortedKeys(obj), ", "))
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// outputTemplateFile renders data with the Go template stored at path. The template sees the
+// JSON form of data, so fields are referenced by their JSON names, and sprig functions are available.
+func outputTemplateFile(data interface{}, path string) error {
+	if path == "" {
+		return fmt.Errorf("template-file output requires a path (e.g. -o template-file=report.tmpl)")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template file: %w", err)
+	}
+	tmpl, err := template.New(path).Funcs(sprig.TxtFuncMap()).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse template file %s: %w", path, err)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode data for template: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("failed to decode data for template: %w", err)
+	}
+	if err := tmpl.Execute(os.Stdout, generic); err != nil {
+		return fmt.Errorf("failed to execute template file %s: %w", path, err)
+	}
+	return nil
+}
+
+// printNoData reports an empty table. With NoHeaders the message goes to stderr, so that a
+// script reading the rows sees no output at all.
+func printNoData() {
+	if tableOptions.NoHeaders {
+		fmt.Fprintln(os.Stderr, "No data found")
+		return
+	}
+	fmt.Println("No data found")
+}
+
+// outputGoTemplateFile renders data with outputGoTemplate, reading the template from path
+func outputGoTemplateFile(data interface{}, path string) error {
+	if path == "" {
+		return fmt.Errorf("go-template-file output requires a path (e.g. -o go-template-file=report.tmpl)")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template file: %w", err)
+	}
+	return outputGoTemplate(data, path, string(content))
+}
+
+// outputGoTemplate renders data with a Go template, as kubectl's go-template output does.
+// Unlike template-file the template sees the Go value itself, so fields are referenced by
+// their Go names (e.g. {{.Name}}). A slice is rendered once per element, and each rendering
+// that does not end in a newline gets one, so that every element is printed on its own line.
+func outputGoTemplate(data interface{}, name, text string) error {
+	if text == "" {
+		return fmt.Errorf("go-template output requires a template (e.g. -o go-template='{{.Name}}')")
+	}
+	tmpl, err := template.New(name).Funcs(sprig.TxtFuncMap()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	items := []interface{}{data}
+	if v := reflect.Indirect(reflect.ValueOf(data)); v.Kind() == reflect.Slice {
+		items = items[:0]
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, v.Index(i).Interface())
+		}
+	}
+	var buf bytes.Buffer
+	for _, item := range items {
+		buf.Reset()
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return fmt.Errorf("failed to execute template %s: %w", name, err)
+		}
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func outputTable(data interface{}) error {
 	v := reflect.ValueOf(data)
 	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			printNoData()
+			return nil
+		}
 		v = v.Elem()
 	}
 
 	switch v.Kind() {
 	case reflect.Slice:
-		return outputSliceAsTable(v)
+		return outputSliceAsTable(v, nil)
 	case reflect.Struct:
+		// List wrappers such as ServerClassList render their items as rows
+		if items := v.FieldByName("Items"); items.IsValid() && items.Kind() == reflect.Slice {
+			return outputSliceAsTable(items, nil)
+		}
 		return outputStructAsTable(v)
+	case reflect.Map:
+		return outputMapAsTable(v)
 	default:
 		// Fallback to JSON for unsupported types
+		fmt.Fprintln(os.Stderr, "table view not supported for this type, showing JSON")
 		return outputJSON(data)
 	}
 }
 
-func outputSliceAsTable(v reflect.Value) error {
+// outputSliceAsTable renders a slice with one row per item, followed by totals when given
+func outputSliceAsTable(v reflect.Value, totals *TableTotals) error {
 	if v.Len() == 0 {
-		fmt.Println("No data found")
+		printNoData()
 		return nil
 	}
 
-	// Get the first element to determine structure
-	first := v.Index(0)
-	if first.Kind() == reflect.Ptr {
-		first = first.Elem()
+	// Determine the structure from the element type, which nil elements don't hide
+	t := v.Type().Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
 
-	if first.Kind() != reflect.Struct {
+	if t.Kind() == reflect.Map {
+		return outputMapsAsTable(v)
+	}
+
+	if t.Kind() != reflect.Struct {
 		// For non-struct slices, just print each item
 		for i := 0; i < v.Len(); i++ {
 			fmt.Println(v.Index(i).Interface())
@@ -73,54 +347,484 @@ func outputSliceAsTable(v reflect.Value) error {
 		return nil
 	}
 
-	// Get field names from the first struct
-	t := first.Type()
+	fields, err := tableFields(t)
+	if err != nil {
+		return err
+	}
+	rows := make([][]tableCell, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		rows = append(rows, tableCells(v.Index(i), t, fields))
+	}
+
+	if totals == nil {
+		return printTable(tableHeaders(t, fields), rows, nil)
+	}
+	var totalsRow []tableCell
+	if totals.Row != nil {
+		row := reflect.Indirect(reflect.ValueOf(totals.Row))
+		if row.Type() != t {
+			return fmt.Errorf("totals row is a %s, not a %s", row.Type(), t)
+		}
+		// The totals line is not colorized, so its cells carry no column name
+		for _, j := range fields {
+			totalsRow = append(totalsRow, tableCell{value: formatCell(row.Field(j))})
+		}
+	}
+	if err := printTable(tableHeaders(t, fields), rows, totalsRow); err != nil {
+		return err
+	}
+	for _, line := range totals.Summary {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// tableHeaders returns the column headers of the given fields of a row struct type
+func tableHeaders(t reflect.Type, fields []int) []string {
 	var headers []string
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		if field.IsExported() {
-			headers = append(headers, strings.ToUpper(field.Name))
+	for _, i := range fields {
+		headers = append(headers, strings.ToUpper(t.Field(i).Name))
+	}
+	return headers
+}
+
+// tableCell is one cell of a table. column is the field the cell shows, which decides
+// whether colorizeStatus colors it; header and totals cells leave it empty.
+type tableCell struct {
+	value  string
+	column string
+}
+
+// tableCells returns the cells of one row struct of type t. A nil pointer renders as a row
+// of empty cells.
+func tableCells(item reflect.Value, t reflect.Type, fields []int) []tableCell {
+	item = reflect.Indirect(item)
+	var cells []tableCell
+	for _, j := range fields {
+		cell := tableCell{column: t.Field(j).Name}
+		if item.IsValid() {
+			cell.value = formatCell(item.Field(j))
 		}
+		cells = append(cells, cell)
 	}
+	return cells
+}
 
-	// Print headers
-	fmt.Println(strings.Join(headers, "\t"))
-	fmt.Println(strings.Repeat("-", len(strings.Join(headers, "\t"))))
+// tableRow returns the cells of one row struct of type t as text, with status columns colorized
+func tableRow(item reflect.Value, t reflect.Type, fields []int) []string {
+	var values []string
+	for _, cell := range tableCells(item, t, fields) {
+		values = append(values, colorizeStatus(cell.column, cell.value))
+	}
+	return values
+}
 
-	// Print data rows
-	for i := 0; i < v.Len(); i++ {
-		item := v.Index(i)
-		if item.Kind() == reflect.Ptr {
-			item = item.Elem()
+// tablePadding is the number of spaces between table columns
+const tablePadding = 2
+
+// cellSanitizer keeps every cell on one line, so a value containing tabs or newlines cannot
+// break the grid
+var cellSanitizer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// printTable prints headers and rows in columns aligned with text/tabwriter, with a dashed
+// line spanning the rendered width under the headers. A non-nil totals row is printed last,
+// under a second dashed line. With NoHeaders only the rows and totals row are printed.
+//
+// Cells are aligned as plain text and status cells colored afterwards, because tabwriter
+// would count color escape codes towards the column widths.
+func printTable(headers []string, rows [][]tableCell, totals []tableCell) error {
+	lines := make([][]tableCell, 0, len(rows)+2)
+	if !tableOptions.NoHeaders {
+		var header []tableCell
+		for _, h := range headers {
+			header = append(header, tableCell{value: h})
 		}
+		lines = append(lines, header)
+	}
+	lines = append(lines, rows...)
+	if totals != nil {
+		lines = append(lines, totals)
+	}
 
-		var values []string
-		for j := 0; j < item.NumField(); j++ {
-			field := item.Type().Field(j)
-			if field.IsExported() {
-				value := item.Field(j)
-				values = append(values, fmt.Sprintf("%v", value.Interface()))
-			}
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, tablePadding, ' ', 0)
+	for _, line := range lines {
+		values := make([]string, len(line))
+		for i := range line {
+			line[i].value = cellSanitizer.Replace(line[i].value)
+			values[i] = line[i].value
 		}
-		fmt.Println(strings.Join(values, "\t"))
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
 	}
 
+	rendered := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	width := 0
+	for i := range rendered {
+		rendered[i] = strings.TrimRight(rendered[i], " ")
+		width = max(width, utf8.RuneCountInString(rendered[i]))
+	}
+	separator := strings.Repeat("-", width)
+	offsets := columnOffsets(lines)
+	for i, text := range rendered {
+		separated := (i == 1 && len(rows) > 0) || (totals != nil && i == len(rendered)-1)
+		if separated && !tableOptions.NoHeaders {
+			fmt.Println(separator)
+		}
+		fmt.Println(colorizeLine(text, lines[i], offsets))
+	}
 	return nil
 }
 
+// columnOffsets returns the rune offset at which each column starts, computed as tabwriter
+// lays them out: every column but the last is as wide as its widest cell plus tablePadding.
+func columnOffsets(lines [][]tableCell) []int {
+	var widths []int
+	for _, line := range lines {
+		for i, cell := range line {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell.value)+tablePadding)
+		}
+	}
+	offsets := make([]int, len(widths))
+	for i := 1; i < len(widths); i++ {
+		offsets[i] = offsets[i-1] + widths[i-1]
+	}
+	return offsets
+}
+
+// colorizeLine colors the status cells of one aligned line of a table
+func colorizeLine(text string, cells []tableCell, offsets []int) string {
+	runes := []rune(text)
+	var b strings.Builder
+	pos := 0
+	for i, cell := range cells {
+		colored := colorizeStatus(cell.column, cell.value)
+		if colored == cell.value {
+			continue
+		}
+		start := offsets[i]
+		b.WriteString(string(runes[pos:start]))
+		b.WriteString(colored)
+		pos = start + utf8.RuneCountInString(cell.value)
+	}
+	b.WriteString(string(runes[pos:]))
+	return b.String()
+}
+
 func outputStructAsTable(v reflect.Value) error {
 	t := v.Type()
+	fields, err := tableFields(t)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]tableCell
+	for _, i := range fields {
+		rows = append(rows, []tableCell{
+			{value: strings.ToUpper(t.Field(i).Name)},
+			{value: formatCell(v.Field(i)), column: t.Field(i).Name},
+		})
+	}
+	return printTable([]string{"FIELD", "VALUE"}, rows, nil)
+}
+
+// formatCell renders one table cell. Pointers are followed, so a cell never shows a memory
+// address, and nil values are empty. Times use RFC 3339, slices of scalars are joined with
+// commas and other structs, maps and slices are shown as compact JSON.
+func formatCell(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return ""
+		}
+		var items []string
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+				if item.IsNil() {
+					break
+				}
+				item = item.Elem()
+			}
+			switch item.Kind() {
+			case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr, reflect.Interface:
+				return formatJSONCell(v)
+			}
+			items = append(items, fmt.Sprintf("%v", item.Interface()))
+		}
+		return strings.Join(items, ",")
+	case reflect.Map:
+		if v.IsNil() {
+			return ""
+		}
+		return formatJSONCell(v)
+	case reflect.Struct:
+		return formatJSONCell(v)
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// formatJSONCell renders a composite table cell as compact JSON
+func formatJSONCell(v reflect.Value) string {
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	return string(b)
+}
+
+// sortedMapKeys returns the keys of a map value as sorted strings
+func sortedMapKeys(m reflect.Value) []string {
+	var keys []string
+	for _, k := range m.MapKeys() {
+		keys = append(keys, fmt.Sprintf("%v", k.Interface()))
+	}
+	sort.Strings(keys)
+	return keys
+}
 
-	fmt.Println("FIELD\tVALUE")
-	fmt.Println("-----\t-----")
+// mapIndex looks up a map entry by its string form
+func mapIndex(m reflect.Value, key string) string {
+	for _, k := range m.MapKeys() {
+		if fmt.Sprintf("%v", k.Interface()) == key {
+			return formatCell(m.MapIndex(k))
+		}
+	}
+	return ""
+}
 
+// outputMapsAsTable renders a slice of maps, using the union of all keys as columns
+func outputMapsAsTable(v reflect.Value) error {
+	seen := make(map[string]bool)
+	var keys []string
+	for i := 0; i < v.Len(); i++ {
+		item := reflect.Indirect(v.Index(i))
+		if item.Kind() == reflect.Interface {
+			item = reflect.Indirect(item.Elem())
+		}
+		for _, k := range sortedMapKeys(item) {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	keys, err := filterColumns(keys)
+	if err != nil {
+		return err
+	}
+
+	var headers []string
+	for _, k := range keys {
+		headers = append(headers, strings.ToUpper(k))
+	}
+	var rows [][]tableCell
+	for i := 0; i < v.Len(); i++ {
+		item := reflect.Indirect(v.Index(i))
+		if item.Kind() == reflect.Interface {
+			item = reflect.Indirect(item.Elem())
+		}
+		var cells []tableCell
+		for _, k := range keys {
+			cells = append(cells, tableCell{value: mapIndex(item, k), column: k})
+		}
+		rows = append(rows, cells)
+	}
+	return printTable(headers, rows, nil)
+}
+
+// outputMapAsTable renders a single map as FIELD/VALUE rows
+func outputMapAsTable(v reflect.Value) error {
+	keys, err := filterColumns(sortedMapKeys(v))
+	if err != nil {
+		return err
+	}
+
+	var rows [][]tableCell
+	for _, k := range keys {
+		rows = append(rows, []tableCell{
+			{value: strings.ToUpper(k)},
+			{value: mapIndex(v, k), column: k},
+		})
+	}
+	return printTable([]string{"FIELD", "VALUE"}, rows, nil)
+}
+
+// colorizeStatus colors the value of a status or phase column: green for healthy states,
+// yellow for transitional ones and red for failures. Other columns and unknown values are
+// returned unchanged. fatih/color disables itself for --no-color, NO_COLOR and non-terminals.
+func colorizeStatus(column, value string) string {
+	if !strings.EqualFold(column, "status") && !strings.EqualFold(column, "phase") {
+		return value
+	}
+	v := strings.ToLower(value)
+	switch {
+	case strings.Contains(v, "fail") || strings.Contains(v, "error") || v == "unhealthy" || v == "degraded":
+		return color.RedString(value)
+	case v == "ready" || v == "running" || v == "healthy" || v == "active" || v == "succeeded" || v == "fulfilled":
+		return color.GreenString(value)
+	case v == "provisioning" || v == "pending" || v == "creating" || v == "updating" || v == "deleting" || v == "terminating" || v == "waiting":
+		return color.YellowString(value)
+	}
+	return value
+}
+
+// columnName returns the JSON name of a struct field, falling back to the field name
+func columnName(field reflect.StructField) string {
+	if tag := field.Tag.Get("json"); tag != "" {
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// excludedColumns resolves ExcludeColumns against the valid column names of a table.
+// Each column is identified by its aliases; an excluded name matching none of them is an error.
+func excludedColumns(aliases [][]string) (map[int]bool, error) {
+	excluded := make(map[int]bool)
+	var unknown []string
+	for _, name := range tableOptions.ExcludeColumns {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for i, names := range aliases {
+			for _, alias := range names {
+				if strings.EqualFold(alias, name) {
+					excluded[i] = true
+					found = true
+				}
+			}
+		}
+		if !found {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		var valid []string
+		for _, names := range aliases {
+			valid = append(valid, names[0])
+		}
+		return nil, fmt.Errorf("unknown column(s) %s; valid columns: %s", strings.Join(unknown, ", "), strings.Join(valid, ", "))
+	}
+	return excluded, nil
+}
+
+// selectedColumns resolves Columns against the valid column names of a table, returning the
+// positions of the named columns in the order given, or every position when Columns is
+// empty. A name matching none of the aliases is an error, as is a selection matching nothing.
+func selectedColumns(aliases [][]string) ([]int, error) {
+	var selected []int
+	seen := make(map[int]bool)
+	var unknown []string
+	for _, name := range tableOptions.Columns {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for i, names := range aliases {
+			for _, alias := range names {
+				if strings.EqualFold(alias, name) && !found {
+					found = true
+					if !seen[i] {
+						seen[i] = true
+						selected = append(selected, i)
+					}
+				}
+			}
+		}
+		if !found {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		var valid []string
+		for _, names := range aliases {
+			valid = append(valid, names[0])
+		}
+		return nil, fmt.Errorf("unknown column(s) %s; valid columns: %s", strings.Join(unknown, ", "), strings.Join(valid, ", "))
+	}
+	if len(selected) > 0 {
+		return selected, nil
+	}
+	all := make([]int, len(aliases))
+	for i := range all {
+		all[i] = i
+	}
+	return all, nil
+}
+
+// tableFields returns the indexes of the exported fields of t that should be rendered
+func tableFields(t reflect.Type) ([]int, error) {
+	var indexes []int
+	var aliases [][]string
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 		if field.IsExported() {
-			value := v.Field(i)
-			fmt.Printf("%s\t%v\n", strings.ToUpper(field.Name), value.Interface())
+			indexes = append(indexes, i)
+			aliases = append(aliases, []string{columnName(field), field.Name})
 		}
 	}
+	selected, err := selectedColumns(aliases)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := excludedColumns(aliases)
+	if err != nil {
+		return nil, err
+	}
+	var fields []int
+	for _, n := range selected {
+		if !excluded[n] {
+			fields = append(fields, indexes[n])
+		}
+	}
+	return fields, nil
+}
 
-	return nil
+// filterColumns selects and orders the columns of a map-based table and drops excluded keys
+func filterColumns(keys []string) ([]string, error) {
+	var aliases [][]string
+	for _, k := range keys {
+		aliases = append(aliases, []string{k})
+	}
+	selected, err := selectedColumns(aliases)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := excludedColumns(aliases)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []string
+	for _, i := range selected {
+		if !excluded[i] {
+			filtered = append(filtered, keys[i])
+		}
+	}
+	return filtered, nil
 }
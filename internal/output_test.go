@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// captureStdout returns what fn writes to os.Stdout
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan []byte)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- out
+	}()
+	fnErr := fn()
+	os.Stdout = stdout
+	w.Close()
+	out := <-done
+	r.Close()
+	if fnErr != nil {
+		t.Fatalf("unexpected error: %v", fnErr)
+	}
+	return string(out)
+}
+
+// checkGolden compares got with testdata/name, rewriting the file with -update
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run go test -update to create it): %v", err)
+	}
+	if !bytes.Equal(want, []byte(got)) {
+		t.Errorf("output does not match %s\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func testCloudspace() *rxtspot.CloudSpace {
+	cs := &rxtspot.CloudSpace{
+		Name:              "prod",
+		Org:               "hooli",
+		CreationTimestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Region:            "us-central-dfw-1",
+		KubernetesVersion: "1.31.1",
+		APIServerEndpoint: "https://prod.spot.rackspace.com:6443",
+		Status:            "Ready",
+		SpotNodepools: []*rxtspot.SpotNodePool{
+			{Name: "spot-a", ServerClass: "gp.vs1.medium-dfw", Desired: 3, BidPrice: "$0.08", WonCount: 3},
+		},
+		OnDemandNodePools: []*rxtspot.OnDemandNodePool{
+			{Name: "od-a", ServerClass: "gp.vs1.large-dfw", Desired: 1},
+		},
+	}
+	cs.SpotNodepools[0].Autoscaling.Enabled = true
+	cs.SpotNodepools[0].Autoscaling.MinNodes = 1
+	cs.SpotNodepools[0].Autoscaling.MaxNodes = 5
+	return cs
+}
+
+func TestOutputDataGolden(t *testing.T) {
+	color.NoColor = true
+	SetTableOptions(TableOptions{})
+
+	var nilCloudspace *rxtspot.CloudSpace
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{"cloudspace", testCloudspace()},
+		{"cloudspaces", []*rxtspot.CloudSpace{testCloudspace(), nil}},
+		{"empty", []rxtspot.CloudSpace{}},
+		{"serverclasses", &rxtspot.ServerClassList{Items: []rxtspot.ServerClass{
+			{Name: "gp.vs1.medium-dfw", Category: "General Purpose", Region: "us-central-dfw-1", MinBidPricePerHour: "$0.002", CurrentMarketPricePerHour: "$0.01", Resources: rxtspot.Resource{CPU: "2", Memory: "4GB"}},
+			{Name: "gp.vs1.large-dfw", Category: "General Purpose", Region: "us-central-dfw-1", MinBidPricePerHour: "$0.004", CurrentMarketPricePerHour: "$0.02", Resources: rxtspot.Resource{CPU: "4", Memory: "8GB"}},
+		}}},
+		{"nil", nilCloudspace},
+	}
+	for _, tt := range tests {
+		for _, format := range []string{"json", "yaml", "table"} {
+			t.Run(tt.name+"/"+format, func(t *testing.T) {
+				got := captureStdout(t, func() error { return OutputData(tt.data, format) })
+				checkGolden(t, tt.name+"."+format+".golden", got)
+			})
+		}
+	}
+}
+
+func TestNormalizeOutputFormat(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"json", "json"},
+		{"YAML", "yaml"},
+		{"Table", "table"},
+		{" JSON ", "json"},
+		{"template-file=Reports/Pods.tmpl", "template-file=Reports/Pods.tmpl"},
+		{"Template-File=Reports/Pods.tmpl", "template-file=Reports/Pods.tmpl"},
+		{"GO-TEMPLATE={{.Name}}", "go-template={{.Name}}"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeOutputFormat(tt.in); got != tt.want {
+			t.Errorf("NormalizeOutputFormat(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if err := ValidateOutputFormat(tt.in); err != nil {
+			t.Errorf("ValidateOutputFormat(%q) = %v, want nil", tt.in, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+// NodePoolPageSize is the number of node pools requested per page by the paged list methods
+const NodePoolPageSize = 100
+
+// listNodePoolPages requests the node pools of a cloudspace one page at a time. Each page is
+// decoded into a fresh value from newPage and handed to next, which returns the continue token
+// of the following page. It stops at the last page, or at the first error of a request or next.
+func (c *Client) listNodePoolPages(ctx context.Context, org, cloudspace, kind string, newPage func() interface{}, next func(page interface{}) (string, error)) error {
+	if c.sdk == nil {
+		return fmt.Errorf("paged node pool listing is not supported by this client")
+	}
+	orgID, err := c.orgNamespace(ctx, org)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	query.Set("labelSelector", "ngpc.rxt.io/cloudspace="+cloudspace)
+	query.Set("limit", strconv.Itoa(NodePoolPageSize))
+	for {
+		page := newPage()
+		listURL := fmt.Sprintf("%s/apis/ngpc.rxt.io/v1/namespaces/%s/%s?%s", c.sdk.BaseURL, orgID, kind, query.Encode())
+		if err := c.rawRequest(ctx, http.MethodGet, listURL, nil, page); err != nil {
+			return err
+		}
+		token, err := next(page)
+		if err != nil || token == "" {
+			return err
+		}
+		query.Set("continue", token)
+	}
+}
+
+// ListSpotNodePoolPages lists the spot node pools of a cloudspace like the SDK's
+// ListSpotNodePools, but requests them in pages and calls fn with each page as it arrives,
+// so that large listings can be written out before the last page is fetched.
+func (c *Client) ListSpotNodePoolPages(ctx context.Context, org, cloudspace string, fn func([]*rxtspot.SpotNodePool) error) error {
+	return c.listNodePoolPages(ctx, org, cloudspace, "spotnodepools",
+		func() interface{} { return &rxtspot.SpotNodePoolListResponse{} },
+		func(page interface{}) (string, error) {
+			resp := page.(*rxtspot.SpotNodePoolListResponse)
+			pools := make([]*rxtspot.SpotNodePool, 0, len(resp.Items))
+			for _, item := range resp.Items {
+				pools = append(pools, spotNodePoolFromObject(org, rxtspot.SpotNodePoolGetResponse(item)))
+			}
+			return resp.Metadata.Continue, fn(pools)
+		})
+}
+
+// ListOnDemandNodePoolPages is ListSpotNodePoolPages for on-demand node pools. Unlike the
+// SDK's ListOnDemandNodePools it does not look up the on-demand price of every pool, so
+// OnDemandPricePerHour is left empty.
+func (c *Client) ListOnDemandNodePoolPages(ctx context.Context, org, cloudspace string, fn func([]*rxtspot.OnDemandNodePool) error) error {
+	return c.listNodePoolPages(ctx, org, cloudspace, "ondemandnodepools",
+		func() interface{} { return &onDemandNodePoolListObject{} },
+		func(page interface{}) (string, error) {
+			resp := page.(*onDemandNodePoolListObject)
+			pools := make([]*rxtspot.OnDemandNodePool, 0, len(resp.Items))
+			for _, item := range resp.Items {
+				pools = append(pools, onDemandNodePoolFromObject(org, item))
+			}
+			return resp.Metadata.Continue, fn(pools)
+		})
+}
@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+// PatchNodePoolSpec applies spec to a node pool as a JSON merge patch. Unlike the SDK's
+// update methods, which omit zero values, it can set desired or autoscaling bounds to zero
+// and remove custom annotations by setting them to nil.
+func (c *Client) PatchNodePoolSpec(ctx context.Context, org, name string, spot bool, spec map[string]interface{}) error {
+	return c.patchNodePool(ctx, org, name, spot, map[string]interface{}{"spec": spec})
+}
+
+// UpdateSpotNodePool applies the same update as the SDK's UpdateSpotNodePool. Unlike the
+// SDK's, its errors keep the status code, so a conflict satisfies rxtspot.IsConflict.
+func (c *Client) UpdateSpotNodePool(ctx context.Context, org string, pool rxtspot.SpotNodePool) error {
+	return c.patchNodePool(ctx, org, pool.Name, true, map[string]interface{}{
+		"spec": spotNodePoolUpdateSpec(pool),
+	})
+}
+
+// UpdateSpotNodePoolIfUnchanged applies the same update as the SDK's UpdateSpotNodePool, but
+// only if the pool is still at resourceVersion. When it changed in the meantime the API
+// rejects the update and the error satisfies rxtspot.IsConflict.
+func (c *Client) UpdateSpotNodePoolIfUnchanged(ctx context.Context, org string, pool rxtspot.SpotNodePool, resourceVersion string) error {
+	return c.patchNodePool(ctx, org, pool.Name, true, map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": resourceVersion},
+		"spec":     spotNodePoolUpdateSpec(pool),
+	})
+}
+
+// UpdateOnDemandNodePool applies the same update as the SDK's UpdateOnDemandNodePool. Unlike
+// the SDK's, its errors keep the status code, so a conflict satisfies rxtspot.IsConflict.
+func (c *Client) UpdateOnDemandNodePool(ctx context.Context, org string, pool rxtspot.OnDemandNodePool) error {
+	return c.patchNodePool(ctx, org, pool.Name, false, map[string]interface{}{
+		"spec": rxtspot.OnDemandNodePoolUpdateSpec{
+			Desired:           pool.Desired,
+			CustomAnnotations: pool.CustomAnnotations,
+			CustomLabels:      pool.CustomLabels,
+			CustomTaints:      pool.CustomTaints,
+			Autoscaling: rxtspot.AutoscalingInt64Update{
+				Enabled:  pool.Autoscaling.Enabled,
+				MinNodes: int64(pool.Autoscaling.MinNodes),
+				MaxNodes: int64(pool.Autoscaling.MaxNodes),
+			},
+		},
+	})
+}
+
+// spotNodePoolUpdateSpec returns the spec the SDK's UpdateSpotNodePool sends for pool
+func spotNodePoolUpdateSpec(pool rxtspot.SpotNodePool) rxtspot.SpotNodePoolUpdateSpec {
+	return rxtspot.SpotNodePoolUpdateSpec{
+		Desired:           pool.Desired,
+		BidPrice:          pool.BidPrice,
+		CustomAnnotations: pool.CustomAnnotations,
+		CustomLabels:      pool.CustomLabels,
+		CustomTaints:      pool.CustomTaints,
+		Autoscaling: rxtspot.AutoscalingInt64Update{
+			Enabled:  pool.Autoscaling.Enabled,
+			MinNodes: pool.Autoscaling.MinNodes,
+			MaxNodes: pool.Autoscaling.MaxNodes,
+		},
+	}
+}
+
+// NodePoolResourceVersion returns the current resource version of a node pool, which changes
+// on every modification
+func (c *Client) NodePoolResourceVersion(ctx context.Context, org, name string, spot bool) (string, error) {
+	var obj struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}
+	if err := c.nodePoolRequest(ctx, http.MethodGet, org, name, spot, nil, &obj); err != nil {
+		return "", err
+	}
+	return obj.Metadata.ResourceVersion, nil
+}
+
+// patchNodePool sends body to a node pool as a JSON merge patch
+func (c *Client) patchNodePool(ctx context.Context, org, name string, spot bool, body map[string]interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	return c.nodePoolRequest(ctx, http.MethodPatch, org, name, spot, data, nil)
+}
+
+// nodePoolRequest sends a raw request for a node pool resource and decodes the response into
+// out when it is not nil
+func (c *Client) nodePoolRequest(ctx context.Context, method, org, name string, spot bool, body []byte, out interface{}) error {
+	kind := "ondemandnodepools"
+	if spot {
+		kind = "spotnodepools"
+	}
+	return c.resourceRequest(ctx, method, org, kind, name, body, out)
+}
+
+// resourceRequest sends a raw request for the resource of the given kind (e.g. "cloudspaces")
+// in the namespace of org, or for the collection when name is empty, and decodes the response
+// into out when it is not nil
+func (c *Client) resourceRequest(ctx context.Context, method, org, kind, name string, body []byte, out interface{}) error {
+	if c.sdk == nil {
+		return fmt.Errorf("raw %s requests are not supported by this client", kind)
+	}
+	orgID, err := c.orgNamespace(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/apis/ngpc.rxt.io/v1/namespaces/%s/%s", c.sdk.BaseURL, orgID, kind)
+	if name != "" {
+		url += "/" + name
+	}
+	return c.rawRequest(ctx, method, url, body, out)
+}
+
+// rawRequest sends an authenticated request to the API, bypassing the SDK, and decodes the
+// JSON response into out when it is not nil. Non-2xx responses are returned as
+// *rxtspot.HTTPStatusError so that rxtspot.IsNotFound and IsConflict work on them.
+func (c *Client) rawRequest(ctx context.Context, method, url string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.sdk.Token)
+	switch {
+	case body == nil:
+	case method == http.MethodPatch:
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+	default:
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.sdk.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return &rxtspot.HTTPStatusError{StatusCode: resp.StatusCode, Body: string(b)}
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// orgNamespace returns the API namespace of an organization, normalized the way the SDK does
+func (c *Client) orgNamespace(ctx context.Context, org string) (string, error) {
+	orgs, err := c.ListOrganizations(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list organizations: %w", err)
+	}
+	for _, o := range orgs {
+		if o.Name == org {
+			return strings.ToLower(strings.ReplaceAll(o.ID, "_", "-")), nil
+		}
+	}
+	return "", fmt.Errorf("organization '%s' not found", org)
+}
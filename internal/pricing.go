@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
+)
+
+// ServerClassPrice holds the hourly prices of one server class. Prices the API did not
+// report, or reported in an unparseable form, are 0.
+type ServerClassPrice struct {
+	Market   float64
+	MinBid   float64
+	OnDemand float64
+}
+
+// EffectiveMinBid returns the lowest bid the server class accepts, which is never below the
+// current market price
+func (p ServerClassPrice) EffectiveMinBid() float64 {
+	return max(p.MinBid, p.Market)
+}
+
+// ParsePrice parses an API price such as "$0.085" or "0.085", returning 0 when it is empty or
+// malformed
+func ParsePrice(price string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(price), "$")), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// serverClassCache holds the server class listings fetched during one invocation, keyed by region
+type serverClassCache struct {
+	mu      sync.Mutex
+	entries map[string]*rxtspot.ServerClassList
+}
+
+// ListServerClasses returns the server classes of region, fetching them only on the first call
+// for that region within this client. Pricing changes continuously, so long-running commands
+// that need fresh prices should use GetAPI().ListServerClasses.
+func (c *Client) ListServerClasses(ctx context.Context, region string) (*rxtspot.ServerClassList, error) {
+	c.serverClasses.mu.Lock()
+	list, ok := c.serverClasses.entries[region]
+	c.serverClasses.mu.Unlock()
+	if ok {
+		return list, nil
+	}
+
+	list, err := c.api.ListServerClasses(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = &rxtspot.ServerClassList{}
+	}
+	c.serverClasses.mu.Lock()
+	if c.serverClasses.entries == nil {
+		c.serverClasses.entries = make(map[string]*rxtspot.ServerClassList)
+	}
+	c.serverClasses.entries[region] = list
+	c.serverClasses.mu.Unlock()
+	return list, nil
+}
+
+// RegionPricing returns the prices of every server class in region keyed by class name,
+// using a single ListServerClasses call however many classes are looked up
+func (c *Client) RegionPricing(ctx context.Context, region string) (map[string]ServerClassPrice, error) {
+	list, err := c.ListServerClasses(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list server classes for region %s: %w", region, err)
+	}
+	prices := make(map[string]ServerClassPrice, len(list.Items))
+	for _, sc := range list.Items {
+		prices[sc.Name] = ServerClassPrice{
+			Market:   ParsePrice(sc.CurrentMarketPricePerHour),
+			MinBid:   ParsePrice(sc.MinBidPricePerHour),
+			OnDemand: ParsePrice(sc.OnDemandPricePerHour),
+		}
+	}
+	return prices, nil
+}
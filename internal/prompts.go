@@ -3,11 +3,12 @@ package internal
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
 	rxtspot "github.com/rackspace-spot/spot-go-sdk/api/v1"
 	"github.com/rackspace-spot/spotctl/internal/ui"
 )
@@ -21,6 +22,30 @@ const (
 	cniBringYourOwn          = "bring your own CNI"
 )
 
+// promptFetchTimeout bounds each API request made to populate a prompt; zero means no limit
+var promptFetchTimeout time.Duration
+
+// SetPromptFetchTimeout limits how long a prompt waits for the API to list its options
+func SetPromptFetchTimeout(d time.Duration) {
+	promptFetchTimeout = d
+}
+
+// PromptFetchContext derives the context for an API request that populates a prompt
+func PromptFetchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if promptFetchTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, promptFetchTimeout)
+}
+
+// PromptFetchError wraps err from a prompt's API request, naming the timeout when it expired
+func PromptFetchError(fetchCtx context.Context, what string, err error) error {
+	if fetchCtx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s %s", promptFetchTimeout, what)
+	}
+	return fmt.Errorf("failed %s: %w", what, err)
+}
+
 // PromptForRegion prompts the user to select a region from the available regions
 func (c *Client) PromptForRegion(ctx context.Context) (string, error) {
 	return c.PromptForRegionWithDefault(ctx, "")
@@ -28,9 +53,11 @@ func (c *Client) PromptForRegion(ctx context.Context) (string, error) {
 
 // PromptForRegionWithDefault prompts the user to select a region with an optional default using a dropdown
 func (c *Client) PromptForRegionWithDefault(ctx context.Context, defaultRegion string) (string, error) {
-	regions, err := c.api.ListRegions(ctx)
+	fetchCtx, cancel := PromptFetchContext(ctx)
+	regions, err := c.api.ListRegions(fetchCtx)
+	cancel()
 	if err != nil {
-		return "", fmt.Errorf("failed to list available regions: %w", err)
+		return "", PromptFetchError(fetchCtx, "listing available regions", err)
 	}
 
 	if len(regions) == 0 {
@@ -67,9 +94,7 @@ func (c *Client) PromptForRegionWithDefault(ctx context.Context, defaultRegion s
 
 	// Create and run the BubbleTea select prompt
 	model := ui.NewSelectModel(regionOptions)
-	p := tea.NewProgram(model)
-	// Run the program and get the result
-	m, err := p.Run()
+	m, err := RunProgram(ctx, model)
 	if err != nil {
 		return "", fmt.Errorf("error running prompt: %w", err)
 	}
@@ -86,7 +111,7 @@ func (c *Client) PromptForRegionWithDefault(ctx context.Context, defaultRegion s
 	selectedOption := selectedModel.Selected()
 
 	if selectedOption == "" {
-		return c.fallbackRegionPrompt(regions, defaultRegion)
+		return c.fallbackRegionPrompt(ctx, regions, defaultRegion)
 	}
 
 	// Return the actual region name
@@ -94,7 +119,7 @@ func (c *Client) PromptForRegionWithDefault(ctx context.Context, defaultRegion s
 }
 
 // fallbackRegionPrompt provides a fallback method for region selection if the dropdown fails
-func (c *Client) fallbackRegionPrompt(regions []rxtspot.Region, defaultRegion string) (string, error) {
+func (c *Client) fallbackRegionPrompt(ctx context.Context, regions []rxtspot.Region, defaultRegion string) (string, error) {
 	// Find default region index if provided
 	defaultIndex := -1
 	if defaultRegion != "" {
@@ -107,7 +132,7 @@ func (c *Client) fallbackRegionPrompt(regions []rxtspot.Region, defaultRegion st
 	}
 
 	// Display available regions
-	fmt.Println("\nAvailable regions:")
+	fmt.Fprintln(os.Stderr, "\nAvailable regions:")
 	for i, region := range regions {
 		desc := region.Name
 		if region.Description != "" {
@@ -117,12 +142,15 @@ func (c *Client) fallbackRegionPrompt(regions []rxtspot.Region, defaultRegion st
 		if i == defaultIndex {
 			prefix = "* "
 		}
-		fmt.Printf("%s%d. %s\n", prefix, i+1, desc)
+		fmt.Fprintf(os.Stderr, "%s%d. %s\n", prefix, i+1, desc)
 	}
 
 	// Simple input prompt
 	var selectedIndex int
 	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
 		prompt := "\nEnter the number of the region"
 		if defaultIndex >= 0 {
 			prompt = fmt.Sprintf("%s [%d]: ", prompt, defaultIndex+1)
@@ -130,7 +158,7 @@ func (c *Client) fallbackRegionPrompt(regions []rxtspot.Region, defaultRegion st
 			prompt = fmt.Sprintf("%s: ", prompt)
 		}
 
-		fmt.Print(prompt)
+		fmt.Fprint(os.Stderr, prompt)
 		var input string
 		_, err := fmt.Scanln(&input)
 		if err != nil && err.Error() != "unexpected newline" {
@@ -145,12 +173,12 @@ func (c *Client) fallbackRegionPrompt(regions []rxtspot.Region, defaultRegion st
 		// Otherwise parse the input
 		selectedIndex, err = strconv.Atoi(input)
 		if err != nil {
-			fmt.Println("Invalid input. Please enter a number.")
+			fmt.Fprintln(os.Stderr, "Invalid input. Please enter a number.")
 			continue
 		}
 
 		if selectedIndex < 1 || selectedIndex > len(regions) {
-			fmt.Printf("Please enter a number between 1 and %d\n", len(regions))
+			fmt.Fprintf(os.Stderr, "Please enter a number between 1 and %d\n", len(regions))
 			continue
 		}
 
@@ -169,9 +197,11 @@ func (c *Client) PromptForServerClass(ctx context.Context, region string) (strin
 // PromptForServerClassWithBidPrice prompts the user to select a server class and returns the class name, minimum bid price, and on-demand price
 // poolType should be either "spot" or "ondemand" to determine which pricing information to display
 func (c *Client) PromptForServerClassWithBidPrice(ctx context.Context, region, poolType string) (string, string, string, error) {
-	serverClassList, err := c.api.ListServerClasses(ctx, region)
+	fetchCtx, cancel := PromptFetchContext(ctx)
+	serverClassList, err := c.ListServerClasses(fetchCtx, region)
+	cancel()
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to list server classes for region %s: %w", region, err)
+		return "", "", "", PromptFetchError(fetchCtx, "listing server classes for region "+region, err)
 	}
 
 	if serverClassList == nil || len(serverClassList.Items) == 0 {
@@ -216,9 +246,7 @@ func (c *Client) PromptForServerClassWithBidPrice(ctx context.Context, region, p
 	}
 
 	model := ui.NewSelectModel(serverClassOptions)
-	p := tea.NewProgram(model)
-
-	m, err := p.Run()
+	m, err := RunProgram(ctx, model)
 	if err != nil {
 		return "", "", "", fmt.Errorf("error running prompt: %w", err)
 	}
@@ -248,7 +276,7 @@ func (c *Client) PromptForServerClassWithBidPrice(ctx context.Context, region, p
 }
 
 // PromptForKubernetesVersion prompts the user to select a Kubernetes version
-func (c *Client) PromptForKubernetesVersion(defaultVersion string) (string, error) {
+func (c *Client) PromptForKubernetesVersion(ctx context.Context, defaultVersion string) (string, error) {
 	// These are common Kubernetes versions, you might want to fetch them from an API
 	versions := []string{
 		kubernetesVersion1_31_1,
@@ -270,9 +298,7 @@ func (c *Client) PromptForKubernetesVersion(defaultVersion string) (string, erro
 	}
 
 	model := ui.NewSelectModel(versions)
-	p := tea.NewProgram(model)
-
-	m, err := p.Run()
+	m, err := RunProgram(ctx, model)
 	if err != nil {
 		return "", fmt.Errorf("error running prompt: %w", err)
 	}
@@ -295,7 +321,7 @@ func (c *Client) PromptForKubernetesVersion(defaultVersion string) (string, erro
 }
 
 // PromptForCNI prompts the user to select a CNI plugin
-func (c *Client) PromptForCNI(defaultCNI string) (string, error) {
+func (c *Client) PromptForCNI(ctx context.Context, defaultCNI string) (string, error) {
 	cniOptions := []string{
 		cniCalico,
 		cniCilium,
@@ -316,9 +342,7 @@ func (c *Client) PromptForCNI(defaultCNI string) (string, error) {
 	}
 
 	model := ui.NewSelectModel(cniOptions)
-	p := tea.NewProgram(model)
-
-	m, err := p.Run()
+	m, err := RunProgram(ctx, model)
 	if err != nil {
 		return "", fmt.Errorf("error running prompt: %w", err)
 	}
@@ -341,11 +365,9 @@ func (c *Client) PromptForCNI(defaultCNI string) (string, error) {
 }
 
 // PromptForString prompts the user to enter a string value
-func PromptForString(message, defaultValue string) (string, error) {
+func PromptForString(ctx context.Context, message, defaultValue string) (string, error) {
 	model := ui.NewInputModel(message, defaultValue, false)
-	p := tea.NewProgram(model)
-
-	m, err := p.Run()
+	m, err := RunProgram(ctx, model)
 	if err != nil {
 		return "", fmt.Errorf("error running prompt: %w", err)
 	}
@@ -368,19 +390,17 @@ func PromptForString(message, defaultValue string) (string, error) {
 }
 
 // PromptForBidPrice prompts the user to enter a bid price for a spot node pool
-func (c *Client) PromptForBidPrice(message, defaultValue string) (string, error) {
+func (c *Client) PromptForBidPrice(ctx context.Context, message, defaultValue string) (string, error) {
 	if message == "" {
 		message = "Enter your maximum bid price"
 	}
-	return PromptForString(message, defaultValue)
+	return PromptForString(ctx, message, defaultValue)
 }
 
 // Confirm prompts the user for a yes/no confirmation
-func Confirm(message string, defaultYes bool) (bool, error) {
+func Confirm(ctx context.Context, message string, defaultYes bool) (bool, error) {
 	model := ui.NewConfirmModel(message, defaultYes)
-	p := tea.NewProgram(model)
-
-	m, err := p.Run()
+	m, err := RunProgram(ctx, model)
 	if err != nil {
 		return false, fmt.Errorf("error running confirmation: %w", err)
 	}
@@ -393,7 +413,7 @@ func Confirm(message string, defaultYes bool) (bool, error) {
 }
 
 // PromptForNodeCount prompts the user to enter the number of nodes for a node pool
-func (c *Client) PromptForNodeCount(poolType string) (string, error) {
+func (c *Client) PromptForNodeCount(ctx context.Context, poolType string) (string, error) {
 	defaultNodes := "1"
 	if poolType == "" {
 		poolType = "node"
@@ -403,9 +423,7 @@ func (c *Client) PromptForNodeCount(poolType string) (string, error) {
 
 	// Run the input prompt
 	model := ui.NewInputModel(promptMessage, defaultNodes, false)
-	p := tea.NewProgram(model)
-
-	m, err := p.Run()
+	m, err := RunProgram(ctx, model)
 	if err != nil {
 		return "", fmt.Errorf("error running prompt: %w", err)
 	}
@@ -430,13 +448,11 @@ func (c *Client) PromptForNodeCount(poolType string) (string, error) {
 }
 
 // PromptForPoolType prompts the user to select a node pool type (Spot or On-Demand)
-func (c *Client) PromptForPoolType() (string, error) {
+func (c *Client) PromptForPoolType(ctx context.Context) (string, error) {
 	poolTypes := []string{"Spot", "On-Demand"}
 
 	model := ui.NewSelectModel(poolTypes)
-	p := tea.NewProgram(model)
-
-	m, err := p.Run()
+	m, err := RunProgram(ctx, model)
 	if err != nil {
 		return "", fmt.Errorf("error running prompt: %w", err)
 	}
@@ -453,8 +469,8 @@ func (c *Client) PromptForPoolType() (string, error) {
 }
 
 // GetOnDemandPrice retrieves the on-demand price for a given region and server class
-func (c *Client) GetOnDemandPrice(region, serverClass string) (string, error) {
-	serverClassList, err := c.api.ListServerClasses(context.Background(), region)
+func (c *Client) GetOnDemandPrice(ctx context.Context, region, serverClass string) (string, error) {
+	serverClassList, err := c.ListServerClasses(ctx, region)
 	if err != nil {
 		return "", fmt.Errorf("failed to list server classes for region %s: %w", region, err)
 	}
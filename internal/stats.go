@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// requestStats accumulates the API calls made by the HTTP client
+type requestStats struct {
+	mu    sync.Mutex
+	calls map[string]*callStats
+}
+
+// callStats aggregates the requests made to one endpoint
+type callStats struct {
+	count    int
+	errors   int
+	duration time.Duration
+	sent     int64
+	received int64
+}
+
+var httpStats *requestStats
+
+// EnableHTTPStats starts collecting per-endpoint call counts, durations and byte totals
+func EnableHTTPStats() {
+	httpStats = &requestStats{calls: make(map[string]*callStats)}
+}
+
+func (s *requestStats) record(key string, update func(c *callStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.calls[key]
+	if !ok {
+		c = &callStats{}
+		s.calls[key] = c
+	}
+	update(c)
+}
+
+// statsTransport records each request in httpStats. Durations cover the time until
+// response headers arrive; received bytes are counted as the body is read.
+type statsTransport struct {
+	next  http.RoundTripper
+	stats *requestStats
+}
+
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + req.URL.Path
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start)
+	t.stats.record(key, func(c *callStats) {
+		c.count++
+		c.duration += elapsed
+		if req.ContentLength > 0 {
+			c.sent += req.ContentLength
+		}
+		if err != nil {
+			c.errors++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, key: key, stats: t.stats}
+	return resp, nil
+}
+
+// countingBody adds the bytes read from a response body to the stats of its endpoint
+type countingBody struct {
+	io.ReadCloser
+	key   string
+	stats *requestStats
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.stats.record(b.key, func(c *callStats) { c.received += int64(n) })
+	}
+	return n, err
+}
+
+// PrintHTTPStats writes a summary of the API calls made so far. It does nothing unless
+// EnableHTTPStats was called.
+func PrintHTTPStats(w io.Writer) {
+	if httpStats == nil {
+		return
+	}
+	httpStats.mu.Lock()
+	defer httpStats.mu.Unlock()
+
+	keys := make([]string, 0, len(httpStats.calls))
+	for k := range httpStats.calls {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var total callStats
+	fmt.Fprintln(w, "\nAPI call statistics:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  REQUEST\tCALLS\tERRORS\tDURATION\tSENT\tRECEIVED")
+	for _, k := range keys {
+		c := httpStats.calls[k]
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%s\t%d B\t%d B\n", k, c.count, c.errors, c.duration.Round(time.Millisecond), c.sent, c.received)
+		total.count += c.count
+		total.errors += c.errors
+		total.duration += c.duration
+		total.sent += c.sent
+		total.received += c.received
+	}
+	fmt.Fprintf(tw, "  total\t%d\t%d\t%s\t%d B\t%d B\n", total.count, total.errors, total.duration.Round(time.Millisecond), total.sent, total.received)
+	tw.Flush()
+}
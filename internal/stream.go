@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RowStream writes the rows of a listing as they arrive instead of buffering the whole result:
+// newline-delimited JSON with one compact object per row for json output, or table rows below
+// a header written with the first row for table output. It is safe for concurrent use; the
+// rows of one Write call are kept together.
+type RowStream struct {
+	mu      sync.Mutex
+	table   bool
+	fields  []int
+	rowType reflect.Type
+	rows    int
+}
+
+// NewRowStream returns a stream writing in format, which must be json or table. Rows are
+// written in arrival order, so results that need sorting must use OutputData instead.
+func NewRowStream(format string) (*RowStream, error) {
+	if outputField != "" {
+		return nil, fmt.Errorf("--field cannot be used with streamed output")
+	}
+	if rawOutput {
+		return &RowStream{}, nil
+	}
+	switch NormalizeOutputFormat(format) {
+	case "json":
+		return &RowStream{}, nil
+	case "table":
+		return &RowStream{table: true}, nil
+	}
+	return nil, fmt.Errorf("streamed output supports json and table, not %q", format)
+}
+
+// Write writes every element of rows, which must be a slice of structs
+func (s *RowStream) Write(rows interface{}) error {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("streamed rows must be a slice, got %s", v.Kind())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < v.Len(); i++ {
+		if err := s.writeRow(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RowStream) writeRow(item reflect.Value) error {
+	s.rows++
+	if !s.table {
+		b, err := json.Marshal(item.Interface())
+		if err != nil {
+			return fmt.Errorf("failed to encode row: %w", err)
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(b))
+		return err
+	}
+
+	t := item.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if s.rowType == nil {
+		fields, err := tableFields(t)
+		if err != nil {
+			return err
+		}
+		s.rowType, s.fields = t, fields
+		if !tableOptions.NoHeaders {
+			headers := strings.Join(tableHeaders(t, fields), "\t")
+			fmt.Println(headers)
+			fmt.Println(strings.Repeat("-", len(headers)))
+		}
+	} else if t != s.rowType {
+		return fmt.Errorf("streamed rows must all be a %s, got a %s", s.rowType, t)
+	}
+	_, err := fmt.Println(strings.Join(tableRow(item, t, s.fields), "\t"))
+	return err
+}
+
+// Close finishes the stream. A table that received no rows reports that no data was found,
+// as buffered table output does.
+func (s *RowStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.table && s.rows == 0 {
+		printNoData()
+	}
+	return nil
+}
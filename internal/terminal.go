@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+// IsTerminal reports whether the given file is attached to an interactive terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// savedTerminal is the terminal mode captured before any prompt put it into raw mode
+var savedTerminal struct {
+	mu       sync.Mutex
+	state    *term.State
+	prompted bool
+}
+
+// SaveTerminalState records the current mode of the terminal on stdin so RestoreTerminal can
+// return to it. It does nothing when stdin is not a terminal.
+func SaveTerminalState() {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return
+	}
+	state, err := term.GetState(os.Stdin.Fd())
+	if err != nil {
+		return
+	}
+	savedTerminal.mu.Lock()
+	savedTerminal.state = state
+	savedTerminal.mu.Unlock()
+}
+
+// RestoreTerminal puts the terminal back into the mode recorded by SaveTerminalState and shows
+// the cursor again if a prompt ran. It is safe to call any number of times, including while
+// exiting after a panic or signal.
+func RestoreTerminal() {
+	savedTerminal.mu.Lock()
+	defer savedTerminal.mu.Unlock()
+	if savedTerminal.state != nil {
+		_ = term.Restore(os.Stdin.Fd(), savedTerminal.state)
+	}
+	if savedTerminal.prompted && IsTerminal(os.Stdout) {
+		fmt.Fprint(os.Stdout, "\x1b[?25h")
+	}
+	savedTerminal.prompted = false
+}
+
+// RunProgram runs a BubbleTea prompt that is stopped when ctx is cancelled. The terminal is
+// restored when the prompt ends however it ends; BubbleTea itself turns SIGINT and SIGTERM
+// received while the prompt runs into a normal exit. opts are passed on to the program.
+func RunProgram(ctx context.Context, model tea.Model, opts ...tea.ProgramOption) (tea.Model, error) {
+	savedTerminal.mu.Lock()
+	savedTerminal.prompted = true
+	savedTerminal.mu.Unlock()
+	defer RestoreTerminal()
+
+	m, err := tea.NewProgram(model, append([]tea.ProgramOption{tea.WithContext(ctx)}, opts...)...).Run()
+	if err != nil && ctx.Err() != nil {
+		return m, ctx.Err()
+	}
+	return m, err
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	httpDebug     bool
+	pinnedCertSHA string
+)
+
+// SetPinnedCertSHA256 pins the expected SHA-256 fingerprint of the API server's leaf certificate
+func SetPinnedCertSHA256(fingerprint string) {
+	pinnedCertSHA = fingerprint
+}
+
+// normalizeFingerprint lowercases a hex fingerprint and strips colon separators
+func normalizeFingerprint(fingerprint string) (string, error) {
+	fp := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	if b, err := hex.DecodeString(fp); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("invalid certificate fingerprint %q: expected a hex encoded SHA-256 digest", fingerprint)
+	}
+	return fp, nil
+}
+
+// SetHTTPDebug enables logging of every API request to stderr
+func SetHTTPDebug(enabled bool) {
+	httpDebug = enabled
+}
+
+// debugTransport logs the method, URL, status and duration of each request.
+// Headers and bodies are never logged since they carry credentials.
+type debugTransport struct {
+	next http.RoundTripper
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "HTTP %s %s -> error: %v (%s)\n", req.Method, req.URL.Redacted(), err, elapsed)
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "HTTP %s %s -> %d (%s)\n", req.Method, req.URL.Redacted(), resp.StatusCode, elapsed)
+	return resp, nil
+}
+
+// newTransport returns the round tripper used by the API client. When a certificate pin
+// is configured, connections to the host of baseURL must present the pinned leaf certificate.
+func newTransport(baseURL string) (http.RoundTripper, error) {
+	var rt http.RoundTripper = http.DefaultTransport
+	if pinnedCertSHA != "" {
+		pin, err := normalizeFingerprint(pinnedCertSHA)
+		if err != nil {
+			return nil, err
+		}
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+		}
+		host := u.Hostname()
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			VerifyConnection: func(cs tls.ConnectionState) error {
+				if cs.ServerName != host || len(cs.PeerCertificates) == 0 {
+					return nil
+				}
+				sum := sha256.Sum256(cs.PeerCertificates[0].Raw)
+				if got := hex.EncodeToString(sum[:]); got != pin {
+					return fmt.Errorf("certificate pinning failed for %s: expected sha256 %s, got %s", host, pin, got)
+				}
+				return nil
+			},
+		}
+		rt = transport
+	}
+	rt = &clockTransport{next: rt}
+	if httpDebug {
+		rt = &debugTransport{next: rt}
+	}
+	if httpStats != nil {
+		rt = &statsTransport{next: rt, stats: httpStats}
+	}
+	return rt, nil
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	helpKeyStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	helpDescStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+// keyBinding describes one key shown in a prompt's help footer
+type keyBinding struct {
+	keys string
+	desc string
+}
+
+var (
+	selectKeys = []keyBinding{
+		{"↑/k", "up"},
+		{"↓/j", "down"},
+		{"enter/space", "select"},
+		{"q/ctrl+c", "quit"},
+		{"?", "toggle help"},
+	}
+	inputKeys = []keyBinding{
+		{"enter", "submit"},
+		{"←/→", "move cursor"},
+		{"esc/ctrl+c", "cancel"},
+		{"?", "toggle help (when empty)"},
+	}
+	confirmKeys = []keyBinding{
+		{"y/n", "answer"},
+		{"←/→", "toggle default"},
+		{"enter", "accept default"},
+		{"esc/q/ctrl+c", "cancel"},
+		{"?", "toggle help"},
+	}
+)
+
+// renderHelp returns the help footer for bindings. Collapsed, only the toggle and quit keys
+// are listed; expanded, every binding is shown on one line.
+func renderHelp(bindings []keyBinding, expanded bool) string {
+	if !expanded {
+		return helpDescStyle.Render("? for help") + "\n"
+	}
+	parts := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		parts = append(parts, helpKeyStyle.Render(keyLabel(b.keys))+" "+helpDescStyle.Render(b.desc))
+	}
+	return strings.Join(parts, helpDescStyle.Render(" "+Bullet()+" ")) + "\n"
+}
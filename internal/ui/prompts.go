@@ -25,6 +25,7 @@ type SelectModel struct {
 	selected map[int]struct{}
 	done    bool
 	cancelled bool
+	showHelp bool
 }
 
 // NewSelectModel creates a new select prompt model
@@ -35,6 +36,17 @@ func NewSelectModel(choices []string) SelectModel {
 	}
 }
 
+// WithDefault places the cursor on the given choice, if present
+func (m SelectModel) WithDefault(choice string) SelectModel {
+	for i, c := range m.choices {
+		if c == choice {
+			m.cursor = i
+			break
+		}
+	}
+	return m
+}
+
 // Init initializes the model
 func (m SelectModel) Init() tea.Cmd {
 	return nil
@@ -68,6 +80,8 @@ func (m SelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor < 0 {
 				m.cursor = len(m.choices) - 1
 			}
+		case "?":
+			m.showHelp = !m.showHelp
 		}
 	}
 
@@ -97,7 +111,8 @@ func (m SelectModel) View() string {
 		b.WriteString(fmt.Sprintf("%s %s\n", cursor, style.Render(choice)))
 	}
 
-	b.WriteString("\nPress q to quit\n")
+	b.WriteString("\n")
+	b.WriteString(renderHelp(selectKeys, m.showHelp))
 	return b.String()
 }
 
@@ -122,6 +137,7 @@ type InputModel struct {
 	textInput textinput.Model
 	done     bool
 	cancelled bool
+	showHelp bool
 }
 
 // NewInputModel creates a new input prompt model
@@ -135,6 +151,9 @@ func NewInputModel(prompt, defaultValue string, isPassword bool) InputModel {
 	if isPassword {
 		ti.EchoMode = textinput.EchoPassword
 		ti.EchoCharacter = '•'
+		if asciiOnly {
+			ti.EchoCharacter = '*'
+		}
 	}
 
 	return InputModel{
@@ -163,6 +182,11 @@ func (m InputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.done = true
 			return m, tea.Quit
 		}
+		// "?" is ordinary input once something has been typed
+		if msg.String() == "?" && m.textInput.Value() == "" {
+			m.showHelp = !m.showHelp
+			return m, nil
+		}
 	}
 
 	m.textInput, cmd = m.textInput.Update(msg)
@@ -171,7 +195,10 @@ func (m InputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the input prompt
 func (m InputModel) View() string {
-	return m.textInput.View()
+	if m.done {
+		return m.textInput.View()
+	}
+	return m.textInput.View() + "\n" + renderHelp(inputKeys, m.showHelp)
 }
 
 // Value returns the input value
@@ -190,6 +217,7 @@ type ConfirmModel struct {
 	result bool
 	done   bool
 	cancelled bool
+	showHelp bool
 }
 
 // NewConfirmModel creates a new confirmation prompt model
@@ -230,6 +258,8 @@ func (m ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.result = !m.result
 		case "right", "l":
 			m.result = !m.result
+		case "?":
+			m.showHelp = !m.showHelp
 		}
 	}
 
@@ -249,7 +279,7 @@ func (m ConfirmModel) View() string {
 		return ""
 	}
 
-	return prompt
+	return prompt + "\n" + renderHelp(confirmKeys, m.showHelp)
 }
 
 // Result returns the confirmation result
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	stepDoneStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	stepFailedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+)
+
+// StepTracker prints numbered progress for long-running commands, e.g.
+// "[1/4] Creating cloudspace... ✓ done" ("[OK] done" in ASCII mode). A quiet tracker prints nothing.
+type StepTracker struct {
+	out     io.Writer
+	total   int
+	current int
+	open    bool
+	quiet   bool
+}
+
+// NewStepTracker returns a tracker for total steps writing to out
+func NewStepTracker(out io.Writer, total int, quiet bool) *StepTracker {
+	return &StepTracker{out: out, total: total, quiet: quiet}
+}
+
+// Start begins the next step. A step still open from a previous Start is closed as done.
+func (s *StepTracker) Start(format string, args ...interface{}) {
+	if s.open {
+		s.Done()
+	}
+	s.current++
+	s.open = true
+	if s.quiet {
+		return
+	}
+	if s.total > 0 {
+		fmt.Fprintf(s.out, "[%d/%d] ", s.current, s.total)
+	} else {
+		fmt.Fprintf(s.out, "[%d] ", s.current)
+	}
+	fmt.Fprintf(s.out, format+"... ", args...)
+}
+
+// Count returns the number of steps started so far
+func (s *StepTracker) Count() int {
+	return s.current
+}
+
+// Done marks the current step as successful
+func (s *StepTracker) Done() {
+	if !s.open {
+		return
+	}
+	s.open = false
+	if !s.quiet {
+		fmt.Fprintln(s.out, stepDoneStyle.Render(CheckMark()+" done"))
+	}
+}
+
+// Fail marks the current step as failed and returns err unchanged so callers can
+// write `return tracker.Fail(err)`
+func (s *StepTracker) Fail(err error) error {
+	if !s.open {
+		return err
+	}
+	s.open = false
+	if !s.quiet {
+		fmt.Fprintln(s.out, stepFailedStyle.Render(CrossMark()+" failed"))
+	}
+	return err
+}
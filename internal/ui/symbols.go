@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"os"
+	"strings"
+)
+
+// asciiOnly replaces the Unicode markers with ASCII equivalents, for terminals and logs
+// that cannot render UTF-8
+var asciiOnly bool
+
+// SetASCII switches all markers to their ASCII equivalents
+func SetASCII(enabled bool) {
+	asciiOnly = enabled
+}
+
+// ASCII reports whether markers are printed as ASCII
+func ASCII() bool {
+	return asciiOnly
+}
+
+// LocaleSupportsUTF8 reports whether the locale from LC_ALL, LC_CTYPE or LANG (checked in
+// that order, as the C library does) names a UTF-8 character set
+func LocaleSupportsUTF8() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
+
+// CheckMark returns the marker for a successful result
+func CheckMark() string {
+	if asciiOnly {
+		return "[OK]"
+	}
+	return "✓"
+}
+
+// CrossMark returns the marker for a failed result
+func CrossMark() string {
+	if asciiOnly {
+		return "[FAIL]"
+	}
+	return "✗"
+}
+
+// Bullet returns the list item marker
+func Bullet() string {
+	if asciiOnly {
+		return "-"
+	}
+	return "•"
+}
+
+// asciiKeys spells out the arrow keys in help footers
+var asciiKeys = strings.NewReplacer("↑", "up", "↓", "down", "←", "left", "→", "right")
+
+// keyLabel returns keys as printed in the help footer
+func keyLabel(keys string) string {
+	if asciiOnly {
+		return asciiKeys.Replace(keys)
+	}
+	return keys
+}
@@ -1,24 +1,112 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/rackspace-spot/spotctl/internal"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
+// CurrentConfigVersion is the schema version written by SaveConfig. Files without a version
+// were written by releases that stored the config in ~/.spot_config.
+const CurrentConfigVersion = 1
+
 type SpotConfig struct {
-	Org          string `yaml:"org"`
-	RefreshToken string `yaml:"refreshToken"`
-	AccessToken  string `yaml:"accessToken"`
-	Region       string `yaml:"region"`
+	Version         int    `yaml:"version,omitempty"`
+	Org             string `yaml:"org"`
+	RefreshToken    string `yaml:"refreshToken"`
+	AccessToken     string `yaml:"accessToken"`
+	Region          string `yaml:"region"`
+	PinCertSHA256   string `yaml:"pinCertSHA256,omitempty"`
+	OutputFormat    string `yaml:"outputFormat,omitempty"`
+	CredentialStore string `yaml:"credentialStore,omitempty"`
 }
 
-// GetConfigPath returns the ~/.spot_config path
-func GetConfigPath() (string, error) {
+// Credential stores selectable with 'spotctl configure --credential-store'. An empty
+// CredentialStore means the config file.
+const (
+	CredentialStoreFile    = "file"
+	CredentialStoreKeyring = "keyring"
+)
+
+// ValidateCredentialStore reports whether store names a supported credential store
+func ValidateCredentialStore(store string) error {
+	switch store {
+	case CredentialStoreFile, CredentialStoreKeyring:
+		return nil
+	}
+	return fmt.Errorf("unsupported credential store %q (must be %s or %s)", store, CredentialStoreFile, CredentialStoreKeyring)
+}
+
+// UsesKeyring reports whether the refresh token is kept in the OS keyring instead of the file
+func (c *SpotConfig) UsesKeyring() bool {
+	return c.CredentialStore == CredentialStoreKeyring
+}
+
+// keyringSource returns the keyring entry of the config file at path. Every config file has
+// its own entry, so configs selected with --config-dir do not share a token.
+func keyringSource(path string) internal.KeyringTokenSource {
+	return internal.KeyringTokenSource{Account: path}
+}
+
+// moveKeyringToken moves the keyring entry of the config file at from to the one at to
+func moveKeyringToken(from, to string) error {
+	token, err := keyringSource(from).RefreshToken()
+	if err != nil || token == "" {
+		return err
+	}
+	if err := keyringSource(to).StoreRefreshToken(token); err != nil {
+		return err
+	}
+	return keyringSource(from).DeleteRefreshToken()
+}
+
+// DeleteKeyringToken removes the refresh token of the config file in use from the OS keyring
+func DeleteKeyringToken() error {
+	path, err := GetConfigPath()
+	if err != nil {
+		return err
+	}
+	return keyringSource(path).DeleteRefreshToken()
+}
+
+// configDirOverride is set from the --config-dir flag and takes precedence over the environment
+var configDirOverride string
+
+// SetConfigDir overrides the directory holding the config file
+func SetConfigDir(dir string) {
+	configDirOverride = dir
+}
+
+// configDir returns the directory holding the config file and whether it was chosen explicitly
+// through --config-dir or SPOTCTL_CONFIG_DIR. Otherwise it is $XDG_CONFIG_HOME/spotctl, or
+// ~/.config/spotctl when XDG_CONFIG_HOME is unset.
+func configDir() (string, bool, error) {
+	if configDirOverride != "" {
+		dir, err := ExpandPath(configDirOverride)
+		return dir, true, err
+	}
+	if dir := os.Getenv("SPOTCTL_CONFIG_DIR"); dir != "" {
+		dir, err := ExpandPath(dir)
+		return dir, true, err
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" && filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, "spotctl"), false, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", false, err
+	}
+	return filepath.Join(home, ".config", "spotctl"), false, nil
+}
+
+// legacyConfigPath returns the ~/.spot_config path used by earlier releases
+func legacyConfigPath() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -26,12 +114,143 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(home, ".spot_config"), nil
 }
 
+// GetConfigPath returns the config file path. When no directory is set explicitly and only the
+// legacy ~/.spot_config exists, the legacy file is used until 'spotctl config migrate' moves it.
+func GetConfigPath() (string, error) {
+	path, _, err := resolveConfigPath()
+	return path, err
+}
+
+// resolveConfigPath returns the config file path and whether it is the legacy location
+func resolveConfigPath() (string, bool, error) {
+	dir, explicit, err := configDir()
+	if err != nil {
+		return "", false, err
+	}
+	path := filepath.Join(dir, "config")
+	if explicit {
+		return path, false, nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path, false, nil
+	}
+	legacy, err := legacyConfigPath()
+	if err != nil {
+		return path, false, nil
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		return path, false, nil
+	}
+	return legacy, true, nil
+}
+
+// MigrationResult describes a completed config migration
+type MigrationResult struct {
+	From   string `json:"from" yaml:"from"`
+	To     string `json:"to" yaml:"to"`
+	Backup string `json:"backup" yaml:"backup"`
+}
+
+// NeedsMigration reports whether the config file is in the legacy location or predates the
+// current schema version
+func NeedsMigration() (bool, error) {
+	path, legacy, err := resolveConfigPath()
+	if err != nil {
+		return false, err
+	}
+	if legacy {
+		return true, nil
+	}
+	cfg, err := readConfig(path)
+	if err != nil {
+		return false, err
+	}
+	return cfg.Version < CurrentConfigVersion, nil
+}
+
+// MigrateConfig rewrites the config file in the current schema at the XDG location. The original
+// file is kept next to itself with a .bak suffix. It returns nil when there is nothing to migrate.
+func MigrateConfig() (*MigrationResult, error) {
+	needed, err := NeedsMigration()
+	if err != nil || !needed {
+		return nil, err
+	}
+	from, legacy, err := resolveConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := readConfig(from)
+	if err != nil {
+		return nil, err
+	}
+	to := from
+	if legacy {
+		dir, _, err := configDir()
+		if err != nil {
+			return nil, err
+		}
+		to = filepath.Join(dir, "config")
+	}
+
+	backup := from + ".bak"
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(backup, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to back up %s: %w", from, err)
+	}
+	if err := writeConfig(to, cfg); err != nil {
+		return nil, err
+	}
+	if legacy && cfg.UsesKeyring() {
+		if err := moveKeyringToken(from, to); err != nil {
+			return nil, err
+		}
+	}
+	if legacy {
+		if err := os.Remove(from); err != nil {
+			return nil, fmt.Errorf("migrated to %s but failed to remove %s: %w", to, from, err)
+		}
+	}
+	return &MigrationResult{From: from, To: to, Backup: backup}, nil
+}
+
+// ExpandPath expands environment variables and a leading ~ in a user supplied path
+func ExpandPath(path string) (string, error) {
+	path = os.ExpandEnv(path)
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %q: %w", path, err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// migrationWarned ensures the legacy config warning is printed once per invocation
+var migrationWarned bool
+
 func LoadConfig() (*SpotConfig, error) {
-	path, err := GetConfigPath()
+	path, legacy, err := resolveConfigPath()
 	if err != nil {
 		return nil, fmt.Errorf("spot config not found, run 'spotcli configure' to configure your default orgID, token, and region")
 	}
 
+	cfg, err := readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if (legacy || cfg.Version < CurrentConfigVersion) && !migrationWarned {
+		migrationWarned = true
+		fmt.Fprintf(os.Stderr, "Warning: config file %s uses a legacy format, run 'spotctl config migrate' to upgrade it\n", path)
+	}
+	return cfg, nil
+}
+
+// readConfig parses the config file at path
+func readConfig(path string) (*SpotConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if strings.Contains(err.Error(), "no such file or directory") {
@@ -47,23 +266,86 @@ func LoadConfig() (*SpotConfig, error) {
 	return &cfg, nil
 }
 
+// SaveConfig writes cfg to the config file. With the keyring credential store the refresh
+// token is saved in the OS keyring and the file keeps no tokens.
 func SaveConfig(cfg *SpotConfig) error {
 	path, err := GetConfigPath()
 	if err != nil {
 		return err
 	}
+	if !cfg.UsesKeyring() {
+		return writeConfig(path, cfg)
+	}
+	if err := keyringSource(path).StoreRefreshToken(cfg.RefreshToken); err != nil {
+		return err
+	}
+	onDisk := *cfg
+	onDisk.RefreshToken, onDisk.AccessToken = "", ""
+	return writeConfig(path, &onDisk)
+}
 
+// writeConfig writes cfg to path in the current schema version
+func writeConfig(path string, cfg *SpotConfig) error {
+	cfg.Version = CurrentConfigVersion
 	data, err := yaml.Marshal(cfg)
 	if err != nil {
 		return err
 	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
 	return os.WriteFile(path, data, 0600) // 600 = rw-------
 }
 
+// errNoCredentials is returned when neither the config file nor the environment provides a refresh token
+var errNoCredentials = fmt.Errorf("no credentials found, run 'spotctl configure' or set SPOT_REFRESH_TOKEN")
+
+// TokenSource returns where the credentials of cfg are read from: SPOT_REFRESH_TOKEN, then
+// the OS keyring or the tokens stored in the config file, depending on its credential store
+func TokenSource(cfg *SpotConfig) (internal.TokenSource, error) {
+	var stored internal.TokenSource = internal.StaticTokenSource{Refresh: cfg.RefreshToken, Access: cfg.AccessToken}
+	if cfg.UsesKeyring() {
+		path, err := GetConfigPath()
+		if err != nil {
+			return nil, err
+		}
+		stored = keyringSource(path)
+	}
+	return internal.EnvTokenSource{Fallback: stored}, nil
+}
+
+// ResolveTokens replaces the tokens of cfg with those of its TokenSource
+func ResolveTokens(cfg *SpotConfig) error {
+	source, err := TokenSource(cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.RefreshToken, err = source.RefreshToken(); err != nil {
+		return err
+	}
+	cfg.AccessToken, err = source.AccessToken()
+	return err
+}
+
+// GetCLIEssentials loads the config and resolves its tokens through TokenSource, checking that a
+// refresh token is available. SPOT_REFRESH_TOKEN overrides the token from the config file and
+// allows running without a config file at all.
 func GetCLIEssentials(cmd *cobra.Command) (*SpotConfig, error) {
 	cfg, err := LoadConfig()
 	if err != nil {
+		if os.Getenv("SPOT_REFRESH_TOKEN") == "" {
+			if errors.Is(err, os.ErrNotExist) || strings.Contains(err.Error(), "spot config not found") {
+				return nil, errNoCredentials
+			}
+			return nil, err
+		}
+		cfg = &SpotConfig{}
+	}
+	if err := ResolveTokens(cfg); err != nil {
 		return nil, err
 	}
+	if strings.TrimSpace(cfg.RefreshToken) == "" {
+		return nil, errNoCredentials
+	}
 	return cfg, nil
 }